@@ -0,0 +1,82 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+
+	"server/common/model"
+	"server/common/types"
+	"server/node"
+	"server/service"
+)
+
+// dial connects to the chain node and checks that it serves the stored chain, the client must be closed
+func dial(ctx context.Context, chainUrl string, batchSize int) (client *node.Client, err error) {
+	if client, err = node.Dial(chainUrl); err != nil {
+		return
+	}
+	client.SetBatchSize(batchSize)
+	if _, err = check(client, ctx); err != nil {
+		client.Close()
+	}
+	return
+}
+
+// SetHead checks that the stored block matches the chain node, then rolls the stored chain back to it, the blocks
+// after it are indexed again by Run. A negative number clears the database without the check
+func SetHead(ctx context.Context, chainUrl string, number types.Long) error {
+	client, err := dial(ctx, chainUrl, node.DefaultBatchSize)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return setHead(client, ctx, number)
+}
+
+func setHead(c *node.Client, ctx context.Context, number types.Long) error {
+	parsed := &model.Parsed{Block: &model.Block{}}
+	parsed.Number = number
+	if number >= 0 {
+		if pass, err := verifyHead(c, ctx, parsed); err != nil {
+			return err
+		} else if !pass {
+			return fmt.Errorf("stored block %v does not match the chain node", number)
+		}
+	}
+	return service.SetHead(parsed)
+}
+
+// Verify compares count random stored blocks with the chain node, it returns the numbers of the mismatched blocks
+func Verify(ctx context.Context, chainUrl string, count int) (mismatches []types.Long, err error) {
+	client, err := dial(ctx, chainUrl, node.DefaultBatchSize)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+	total := service.GetStats().TotalBlock
+	if total == 0 {
+		return
+	}
+	for i := 0; i < count && ctx.Err() == nil; i++ {
+		number := types.Long(rand.Int63n(total))
+		stored, err := service.GetBlock(fmt.Sprint(number))
+		if err != nil {
+			return mismatches, err
+		}
+		var block *struct {
+			model.Header
+			Transactions []types.Hash `json:"transactions"`
+		}
+		if err = client.CallContext(ctx, &block, "eth_getBlockByNumber", number.Hex(), false); err != nil {
+			return mismatches, err
+		}
+		if block == nil || block.Hash != stored.Hash || block.StateRoot != stored.StateRoot ||
+			types.Long(len(block.Transactions)) != stored.TotalTransaction {
+			log.Printf("%v block mismatch, stored %v\n", number, stored.Hash)
+			mismatches = append(mismatches, number)
+		}
+	}
+	return
+}
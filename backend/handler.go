@@ -21,6 +21,8 @@ var NotFound = fmt.Errorf("not found")
 
 // decode parses the block
 func decode(c *node.Client, ctx context.Context, number types.Long) (parsed *model.Parsed, err error) {
+	// send all the calls of the block to the same node
+	c = c.Pin(number)
 	// Get the block (including the transaction)
 	err = c.CallContext(ctx, &parsed, "eth_getBlockByNumber", number.Hex(), true)
 	if err != nil {
@@ -45,7 +47,7 @@ func decode(c *node.Client, ctx context.Context, number types.Long) (parsed *mod
 		}
 		for i := range reqs {
 			if reqs[i].Error != nil || parsed.CacheTxs[i].GasUsed == 0 {
-				return nil, fmt.Errorf("eth_getTransactionReceipt receipt:%v,err:%v", reqs[i].Result, reqs[i].Error)
+				return nil, fmt.Errorf("eth_getTransactionReceipt %v err:%v", parsed.CacheTxs[i].Hash, reqs[i].Error)
 			}
 			parsed.CacheTxs[i].Timestamp = parsed.Timestamp
 		}
@@ -55,9 +57,18 @@ func decode(c *node.Client, ctx context.Context, number types.Long) (parsed *mod
 			return nil, fmt.Errorf("eth_getLogs err:%v", err)
 		}
 	}
+	decodeFees(parsed)
 	for _, log := range parsed.CacheLogs {
-		if transferLog := utils.UnpackTransferLog(log); transferLog != nil {
-			parsed.CacheTransferLogs = append(parsed.CacheTransferLogs, transferLog...)
+		for _, transferLog := range utils.UnpackTransferLog(log) {
+			switch transferLog := transferLog.(type) {
+			case *model.ERC20Transfer:
+				transferLog.Timestamp = parsed.Timestamp
+			case *model.ERC721Transfer:
+				transferLog.Timestamp = parsed.Timestamp
+			case *model.ERC1155Transfer:
+				transferLog.Timestamp = parsed.Timestamp
+			}
+			parsed.CacheTransferLogs = append(parsed.CacheTransferLogs, transferLog)
 		}
 	}
 	// Parse changed account properties and internal transactions
@@ -67,45 +78,42 @@ func decode(c *node.Client, ctx context.Context, number types.Long) (parsed *mod
 	if err = decodeAccounts(c, ctx, parsed); err != nil {
 		return nil, fmt.Errorf("decodeAccounts err:%v", err)
 	}
+	if err = decodeTokens(c, ctx, parsed); err != nil {
+		return nil, fmt.Errorf("decodeTokens err:%v", err)
+	}
+	if err = decodeProxies(c, ctx, parsed); err != nil {
+		return nil, fmt.Errorf("decodeProxies err:%v", err)
+	}
 	// Parse things specific to erbie
-	err = decodeWH(c, parsed)
+	if profile == ProfileErbie {
+		err = decodeWH(c, parsed)
+	}
 	return
 }
 
-const tracerCode = `
-{
-	details: [],
-	enter: function (log) {
-		const detail = {index:this.details.length, op:log.getType(), from:toHex(log.getFrom()), to:toHex(log.getTo()), gas:log.getGas()};
-		detail.value = detail.op==='DELEGATECALL' ? '0x0' : '0x'+log.getValue().toString(16);
-		this.details.push(detail);
-	},
-	exit: function() {},
-	step: function() {},
-	fault: function() {},
-	result: function(ctx) {
-		if (this.details.length >= 256) {return {error:ctx.error};}
-		for (var i = 0; i < this.details.length; i++) {
-			this.details[i].txHash = toHex(ctx.txHash);
+// decodeFees computes the fee of each transaction and the total and burnt fees of the block
+func decodeFees(parsed *model.Parsed) {
+	totalFees := new(big.Int)
+	for _, tx := range parsed.CacheTxs {
+		// the nodes before london do not return the effective gas price in the receipt
+		price := tx.GasPrice
+		if tx.EffectiveGasPrice != nil {
+			price = *tx.EffectiveGasPrice
+		}
+		fee, _ := new(big.Int).SetString(string(price), 10)
+		if fee == nil {
+			fee = new(big.Int)
 		}
-		return {details:this.details, error:ctx.error};
+		fee.Mul(fee, big.NewInt(int64(tx.GasUsed)))
+		tx.TxFee = types.BigInt(fee.String())
+		totalFees.Add(totalFees, fee)
 	}
-}`
-
-// decodeInternalTxs 获取交易的内部调用详细情况
-func decodeInternalTxs(c *node.Client, ctx context.Context, parsed *model.Parsed) (err error) {
-	for _, tx := range parsed.CacheTxs {
-		execRet := struct {
-			Details []*model.InternalTx `json:"details"`
-			Error   *string             `json:"error"`
-		}{}
-		if err = c.CallContext(ctx, &execRet, "debug_traceTransaction", tx.Hash, map[string]any{"tracer": tracerCode}); err != nil {
-			return
+	parsed.TotalFees, parsed.BurntFees = types.BigInt(totalFees.String()), "0"
+	if parsed.BaseFeePerGas != nil {
+		if baseFee, ok := new(big.Int).SetString(string(*parsed.BaseFeePerGas), 10); ok {
+			parsed.BurntFees = types.BigInt(baseFee.Mul(baseFee, big.NewInt(int64(parsed.GasUsed))).String())
 		}
-		tx.Error = execRet.Error
-		parsed.CacheInternalTxs = append(parsed.CacheInternalTxs, execRet.Details...)
 	}
-	return
 }
 
 // decodeAccount to get account related properties
@@ -153,15 +161,9 @@ func decodeAccounts(c *node.Client, ctx context.Context, parsed *model.Parsed) (
 			}
 		}
 		number := parsed.Number.Hex()
-		info := struct {
-			Nonce   types.Long `json:"Nonce"`
-			Balance *big.Int   `json:"Balance"`
-			//Worm    *struct {
-			//	VoteWeight *big.Int `json:"VoteWeight"`
-			//} `json:"Worm"`
-		}{}
 		for _, address := range modifiedAccounts {
-			if address != types.ZeroAddress && (address[:12] == "0x0000000000" || address[:12] == "0x8000000000") {
+			// the NFT and SNFT addresses of erbie are not accounts
+			if profile == ProfileErbie && address != types.ZeroAddress && (address[:12] == "0x0000000000" || address[:12] == "0x8000000000") {
 				continue
 			}
 			account := &model.Account{Address: address, Number: parsed.Number, SNFTValue: "0"}
@@ -175,21 +177,10 @@ func decodeAccounts(c *node.Client, ctx context.Context, parsed *model.Parsed) (
 				}
 
 			}
-			if err = c.CallContext(ctx, &info, "eth_getAccountInfo", address, number); err != nil {
-				return
-			}
 			account.Timestamp = types.Long(time.Now().Local().Unix())
-			account.Number = parsed.Number
-			account.Nonce = info.Nonce
-			account.Balance = types.BigInt(info.Balance.String())
-			//if info.Worm != nil {
-			//	account.SNFTValue = info.Worm.VoteWeight.String()
-			//} else {
-			//	account.SNFTValue = "0"
-			//}
-			account.SNFTValue = "0"
 			parsed.CacheAccounts = append(parsed.CacheAccounts, account)
 		}
+		return loadAccounts(c, ctx, parsed.CacheAccounts, number)
 	}
 	return
 }
@@ -199,47 +190,45 @@ func write(c *node.Client, ctx context.Context, parsed *model.Parsed) (head type
 	if err != nil || parsed.Number == head {
 		return
 	}
+	// the block does not follow the stored head, walk back to the last stored block that is still on the chain
+	newHead := parsed.Header
 	for parsed.Number = parsed.Number - 2; parsed.Number >= 0; parsed.Number-- {
-		number, pass := parsed.Number.Hex(), false
-		if err = c.CallContext(ctx, &parsed.Block, "eth_getBlockByNumber", number, false); err != nil {
-			return
-		}
-		if pass, err = service.VerifyHead(parsed); err != nil {
+		pass := false
+		if pass, err = verifyHead(c, ctx, parsed); err != nil {
 			return
 		} else if pass {
-			for _, account := range parsed.CacheAccounts {
-				info := struct {
-					Nonce   types.Long `json:"Nonce"`
-					Balance *big.Int   `json:"Balance"`
-					//Worm    *struct {
-					//	VoteWeight *big.Int `json:"VoteWeight"`
-					//} `json:"Worm"`
-				}{}
-				if err = c.Call(&info, "eth_getAccountInfo", account.Address, number); err != nil {
-					return
-				}
-				account.Number = parsed.Number
-				account.Nonce = info.Nonce
-				account.Balance = types.BigInt(info.Balance.String())
-				//if info.Worm != nil {
-				//	account.SNFTValue = info.Worm.VoteWeight.String()
-				//} else {
-				//	account.SNFTValue = "0"
-				//}
-				account.SNFTValue = "0"
-			}
 			break
 		}
 	}
-	return parsed.Number, service.SetHead(parsed)
+	return parsed.Number, service.Fork(parsed, &newHead)
+}
+
+// verifyHead checks that the stored block parsed.Number is the one on the chain node,
+// if so the accounts changed after it are loaded with their state at that block for service.SetHead
+func verifyHead(c *node.Client, ctx context.Context, parsed *model.Parsed) (pass bool, err error) {
+	number := parsed.Number.Hex()
+	if err = c.CallContext(ctx, &parsed.Block, "eth_getBlockByNumber", number, false); err != nil {
+		return
+	} else if parsed.Block == nil {
+		return false, NotFound
+	}
+	if pass, err = service.VerifyHead(parsed); err != nil || !pass {
+		return
+	}
+	for _, account := range parsed.CacheAccounts {
+		account.Number, account.SNFTValue = parsed.Number, "0"
+	}
+	return pass, loadAccounts(c, ctx, parsed.CacheAccounts, number)
 }
 
 func check(c *node.Client, ctx context.Context) (stats *model.Stats, err error) {
 	if err = c.CallContext(ctx, &struct{}{}, "debug_gcStats"); err != nil {
 		return
 	}
-	if err = c.CallContext(ctx, &struct{}{}, "eth_getAccountInfo", types.ZeroAddress, "0x0"); err != nil {
-		return
+	if profile == ProfileErbie {
+		if err = c.CallContext(ctx, &struct{}{}, "eth_getAccountInfo", types.ZeroAddress, "0x0"); err != nil {
+			return
+		}
 	}
 	chainId, genesis, stats := types.Long(0), model.Header{}, service.GetStats()
 	if err = c.CallContext(ctx, &chainId, "eth_chainId"); err != nil {
@@ -0,0 +1,97 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"server/common/model"
+	"server/common/types"
+	"server/node"
+)
+
+// Chain profiles, they select the node methods used to decode the blocks
+const (
+	// ProfileErbie is the erbie chain, the account state comes from eth_getAccountInfo and the erbie stages
+	// (rewards, SNFT, staking, slashing) are decoded
+	ProfileErbie = "erbie"
+	// ProfileEVM is any EVM chain with the eth and debug api, the account state comes from eth_getBalance and
+	// eth_getTransactionCount, the erbie stages are skipped
+	ProfileEVM = "evm"
+)
+
+var profile = ProfileErbie
+
+// SetProfile sets the chain profile, it must be called before the blocks are decoded
+func SetProfile(name string) error {
+	switch name {
+	case ProfileErbie, ProfileEVM:
+		profile = name
+		return nil
+	}
+	return fmt.Errorf("unknown chain profile %q, expected %q or %q", name, ProfileErbie, ProfileEVM)
+}
+
+// loadAccounts sets the balance and nonce of the accounts at the block number in one batch
+func loadAccounts(c *node.Client, ctx context.Context, accounts []*model.Account, number string) (err error) {
+	if len(accounts) == 0 {
+		return
+	}
+	if profile == ProfileEVM {
+		balances, nonces := make([]types.BigInt, len(accounts)), make([]types.Long, len(accounts))
+		reqs := make([]node.BatchElem, 0, 2*len(accounts))
+		for i, account := range accounts {
+			reqs = append(reqs,
+				node.BatchElem{Method: "eth_getBalance", Args: []any{account.Address, number}, Result: &balances[i]},
+				node.BatchElem{Method: "eth_getTransactionCount", Args: []any{account.Address, number}, Result: &nonces[i]},
+			)
+		}
+		if err = c.BatchCallContext(ctx, reqs); err != nil {
+			return
+		}
+		for i, account := range accounts {
+			if err = firstError(reqs[2*i : 2*i+2]); err != nil {
+				return
+			}
+			account.Nonce, account.Balance = nonces[i], balances[i]
+		}
+		return
+	}
+	infos := make([]struct {
+		Nonce   types.Long `json:"Nonce"`
+		Balance *big.Int   `json:"Balance"`
+		//Worm    *struct {
+		//	VoteWeight *big.Int `json:"VoteWeight"`
+		//} `json:"Worm"`
+	}, len(accounts))
+	reqs := make([]node.BatchElem, len(accounts))
+	for i, account := range accounts {
+		reqs[i] = node.BatchElem{Method: "eth_getAccountInfo", Args: []any{account.Address, number}, Result: &infos[i]}
+	}
+	if err = c.BatchCallContext(ctx, reqs); err != nil {
+		return
+	}
+	if err = firstError(reqs); err != nil {
+		return
+	}
+	for i, account := range accounts {
+		account.Nonce, account.Balance = infos[i].Nonce, types.BigInt(infos[i].Balance.String())
+		//if info.Worm != nil {
+		//	account.SNFTValue = info.Worm.VoteWeight.String()
+		//} else {
+		//	account.SNFTValue = "0"
+		//}
+		account.SNFTValue = "0"
+	}
+	return
+}
+
+// firstError returns the first error of the batch elements
+func firstError(reqs []node.BatchElem) error {
+	for _, req := range reqs {
+		if req.Error != nil {
+			return fmt.Errorf("%v %v err:%v", req.Method, req.Args[0], req.Error)
+		}
+	}
+	return nil
+}
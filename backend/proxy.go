@@ -0,0 +1,60 @@
+package backend
+
+import (
+	"context"
+
+	"server/common/model"
+	"server/common/types"
+	"server/common/utils"
+	"server/node"
+)
+
+// decodeProxies detects the proxies created by the block and reads the implementation of the proxies and the upgradeable
+// beacons that emitted Upgraded or BeaconUpgraded
+func decodeProxies(c *node.Client, ctx context.Context, parsed *model.Parsed) (err error) {
+	number := parsed.Number.Hex()
+	detected := make(map[types.Address]bool)
+	add := func(proxy *model.ProxyImplementation, txHash *types.Hash) {
+		proxy.BlockNumber, proxy.TxHash, proxy.Timestamp = parsed.Number, txHash, parsed.Timestamp
+		parsed.CacheProxies = append(parsed.CacheProxies, proxy)
+	}
+	for _, account := range parsed.CacheAccounts {
+		if account.Code == nil || (parsed.Number > 0 && account.CreatedTx == nil) {
+			continue
+		}
+		detected[account.Address] = true
+		var proxy *model.ProxyImplementation
+		if proxy, err = utils.DetectProxy(c, ctx, number, account.Address, *account.Code); err != nil {
+			return
+		}
+		if proxy != nil {
+			add(proxy, account.CreatedTx)
+		}
+	}
+	for _, log := range parsed.CacheLogs {
+		if len(log.Topics) == 0 || (log.Topics[0] != utils.UpgradedTopic && log.Topics[0] != utils.BeaconUpgradedTopic) || detected[log.Address] {
+			continue
+		}
+		detected[log.Address] = true
+		txHash := log.TxHash
+		var proxy *model.ProxyImplementation
+		if proxy, err = utils.DetectProxy(c, ctx, number, log.Address, ""); err != nil {
+			return
+		}
+		if proxy != nil {
+			add(proxy, &txHash)
+			continue
+		}
+		// an upgradeable beacon emits Upgraded too, its proxies follow its implementation
+		if log.Topics[0] == utils.UpgradedTopic {
+			var implementation *types.Address
+			if implementation, err = utils.BeaconImplementation(c, ctx, number, log.Address); err != nil {
+				return
+			}
+			if implementation != nil {
+				add(&model.ProxyImplementation{Address: log.Address, Kind: "beacon", Implementation: *implementation}, &txHash)
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,140 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"server/common/types"
+	"server/node"
+	"server/service"
+)
+
+// ReindexJob is the progress of decoding and writing a stored block range again
+type ReindexJob struct {
+	ID       int64      `json:"id"`                 //job id
+	From     types.Long `json:"from"`               //first block of the range
+	To       types.Long `json:"to"`                 //last block of the range
+	Current  types.Long `json:"current"`            //next block to reindex
+	Done     bool       `json:"done"`               //whether the job has ended
+	Error    string     `json:"error,omitempty"`    //error that ended the job
+	Started  int64      `json:"started"`            //start timestamp
+	Finished int64      `json:"finished,omitempty"` //end timestamp
+}
+
+var jobs = struct {
+	sync.Mutex
+	list []*ReindexJob
+	// ctx stops the jobs, it is set by RunReindexJobs
+	ctx context.Context
+	wg  sync.WaitGroup
+}{}
+
+// RunReindexJobs lets StartReindex start jobs until the context is cancelled, which stops the running jobs,
+// then waits for them to end
+func RunReindexJobs(ctx context.Context) {
+	jobs.Lock()
+	jobs.ctx = ctx
+	jobs.Unlock()
+	<-ctx.Done()
+	// no job is started once the lock is taken after the cancellation
+	jobs.Lock()
+	jobs.Unlock()
+	jobs.wg.Wait()
+}
+
+// StartReindex reindexes the block range in the background, the progress is returned by ReindexJobs
+func StartReindex(chainUrl string, batchSize int, from, to types.Long) (job ReindexJob, err error) {
+	if err = checkRange(from, to); err != nil {
+		return
+	}
+	jobs.Lock()
+	defer jobs.Unlock()
+	if jobs.ctx == nil || jobs.ctx.Err() != nil {
+		return job, fmt.Errorf("reindex jobs are not running")
+	}
+	for _, j := range jobs.list {
+		if !j.Done && j.From <= to && from <= j.To {
+			return job, fmt.Errorf("blocks %v..%v are being reindexed by job %v", j.From, j.To, j.ID)
+		}
+	}
+	j := &ReindexJob{ID: int64(len(jobs.list)) + 1, From: from, To: to, Current: from, Started: time.Now().Unix()}
+	jobs.list = append(jobs.list, j)
+	jobs.wg.Add(1)
+	go func(ctx context.Context) {
+		defer jobs.wg.Done()
+		err := reindexJob(ctx, chainUrl, batchSize, j)
+		jobs.Lock()
+		defer jobs.Unlock()
+		if err != nil {
+			j.Error = err.Error()
+		}
+		j.Done, j.Finished = true, time.Now().Unix()
+	}(jobs.ctx)
+	return *j, nil
+}
+
+// ReindexJobs returns the reindex jobs started since the process started, the latest first
+func ReindexJobs() []ReindexJob {
+	jobs.Lock()
+	defer jobs.Unlock()
+	res := make([]ReindexJob, len(jobs.list))
+	for i, j := range jobs.list {
+		res[len(res)-1-i] = *j
+	}
+	return res
+}
+
+// Reindex decodes and writes the stored blocks from..to again, while the indexer keeps following the chain head
+func Reindex(ctx context.Context, chainUrl string, batchSize int, from, to types.Long) error {
+	if err := checkRange(from, to); err != nil {
+		return err
+	}
+	return reindexJob(ctx, chainUrl, batchSize, &ReindexJob{From: from, To: to, Current: from})
+}
+
+// checkRange checks the range against the database, the stats of the API are behind the indexer process
+func checkRange(from, to types.Long) error {
+	head, err := service.StoredHead()
+	if err != nil {
+		return err
+	}
+	if from < 0 || to < from || to > head {
+		return fmt.Errorf("invalid block range %v..%v, %v blocks are stored", from, to, head+1)
+	}
+	return nil
+}
+
+func reindexJob(ctx context.Context, chainUrl string, batchSize int, job *ReindexJob) (err error) {
+	client, err := dial(ctx, chainUrl, batchSize)
+	if err != nil {
+		return
+	}
+	defer client.Close()
+	for number := job.From; number <= job.To; number++ {
+		if err = reindex(client, ctx, number); err != nil {
+			return
+		}
+		jobs.Lock()
+		job.Current = number + 1
+		jobs.Unlock()
+		if done := number - job.From + 1; done%100 == 0 || number == job.To {
+			log.Printf("reindexed %v/%v blocks, at %v block\n", done, job.To-job.From+1, number)
+		}
+	}
+	return service.RefreshStats()
+}
+
+// reindex replaces the stored rows of the block with the ones decoded again from the chain node
+func reindex(c *node.Client, ctx context.Context, number types.Long) error {
+	parsed, err := decode(c, ctx, number)
+	if err != nil {
+		return fmt.Errorf("%v block parsing error: %v", number, err)
+	}
+	if err = service.Reinsert(parsed); err != nil {
+		return fmt.Errorf("%v block write error: %v", number, err)
+	}
+	return nil
+}
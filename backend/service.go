@@ -10,54 +10,143 @@ import (
 	"server/node"
 )
 
-func Run(chainUrl string, thread int64, interval time.Duration) (err error) {
-	client, stats, ctx := &node.Client{}, &model.Stats{}, context.Background()
+// resubscribeInterval is the interval of trying the newHeads subscription again while polling
+const resubscribeInterval = time.Minute
+
+// Run indexes the chain until the context is cancelled, the block being written is finished and the in-flight
+// decoding is drained before it returns. The indexer stays lag blocks behind the chain head
+func Run(ctx context.Context, chainUrl string, thread, lag int64, interval time.Duration, batchSize int) (err error) {
+	client, stats := &node.Client{}, &model.Stats{}
 	if client, err = node.Dial(chainUrl); err != nil {
 		return
 	}
+	defer client.Close()
+	client.SetBatchSize(batchSize)
 	if stats, err = check(client, ctx); err != nil {
 		return
 	}
-	go loop(client, ctx, stats, thread, interval)
+	if err = registerTokens(client, ctx, types.Long(stats.TotalBlock-1)); err != nil {
+		return
+	}
+	loop(client, ctx, stats, thread, lag, interval)
 	return
 }
 
-func loop(client *node.Client, ctx context.Context, stats *model.Stats, thread int64, interval time.Duration) {
+// sleep pauses for the duration, it returns false when the context is cancelled in the meantime
+func sleep(ctx context.Context, d time.Duration) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// follow keeps the latest chain head in the returned channel, the head is pushed by the newHeads subscription
+// when a node supports it (ws, wss, ipc), otherwise the block height is polled every interval and the
+// subscription is tried again every resubscribeInterval
+func follow(client *node.Client, ctx context.Context, interval time.Duration) <-chan types.Long {
+	latest := make(chan types.Long, 1)
+	publish := func(head types.Long) {
+		// only the latest head is useful, drop the one that has not been consumed
+		select {
+		case <-latest:
+		default:
+		}
+		latest <- head
+	}
+	go func() {
+		for polling := false; ctx.Err() == nil; {
+			if heads, err := client.SubscribeNewHead(ctx); err == nil {
+				log.Println("following the chain head with the newHeads subscription")
+				if head, err := client.BlockNumber(ctx); err == nil {
+					publish(head)
+				}
+				for head := range heads {
+					publish(head)
+				}
+				log.Println("newHeads subscription closed, subscribing again")
+				polling = false
+				sleep(ctx, interval)
+				continue
+			} else if !polling {
+				log.Printf("newHeads subscription unavailable, polling the block height: %v\n", err)
+				polling = true
+			}
+			deadline := time.Now().Add(resubscribeInterval)
+			for last := types.Long(-1); ctx.Err() == nil && time.Now().Before(deadline); sleep(ctx, interval) {
+				head, err := client.BlockNumber(ctx)
+				if err != nil {
+					log.Printf("get block height error: %v\n", err)
+				} else if head != last {
+					publish(head)
+					last = head
+				}
+			}
+		}
+	}()
+	return latest
+}
+
+func loop(client *node.Client, ctx context.Context, stats *model.Stats, thread, lag int64, interval time.Duration) {
 	parsedCh := make(chan *model.Parsed, thread)
 	cache := make(map[types.Long]*model.Parsed)
 	number, taskCount := types.Long(stats.TotalBlock), int64(0)
 	log.Printf("using %v coroutines, starting data analysis from %v block\n", thread, number)
+	defer func() {
+		// the decoding coroutines give up once the context is cancelled
+		for ; taskCount > 0; taskCount-- {
+			<-parsedCh
+		}
+		log.Printf("data analysis stopped at %v block\n", stats.TotalBlock)
+	}()
+	heads := follow(client, ctx, interval)
 	for {
-		max, err := client.BlockNumber(ctx)
-		if err != nil || (number > max && taskCount == 0) {
-			if err != nil {
-				log.Printf("get block height error: %v\n", err)
-			} else {
-				stats.Ready = true
-			}
-			time.Sleep(interval)
+		var head types.Long
+		select {
+		case head = <-heads:
+		case <-ctx.Done():
+			return
 		}
-		for number <= max || taskCount > 0 {
+		// stay lag blocks behind the chain head, the blocks within the lag may still be replaced by a reorg
+		max := head - types.Long(lag)
+		stats.ChainHead = int64(head)
+		for (number <= max || taskCount > 0) && ctx.Err() == nil {
+			// start decoding the new head as soon as it arrives
+			select {
+			case head = <-heads:
+				max, stats.ChainHead = head-types.Long(lag), int64(head)
+			default:
+			}
 			for ; number <= max && taskCount < thread; number++ {
 				go func(number types.Long) {
 					for {
-						if parsed, err := decode(client, ctx, number); err != nil {
-							log.Printf("%v block parsing error: %v\n", number, err)
-							time.Sleep(10 * interval)
-						} else {
+						parsed, err := decode(client, ctx, number)
+						if err == nil {
 							parsedCh <- parsed
-							break
+							return
 						}
+						if ctx.Err() != nil {
+							parsedCh <- nil
+							return
+						}
+						// the unreachable nodes are already failed over by the client, retry soon
+						log.Printf("%v block parsing error: %v\n", number, err)
+						sleep(ctx, interval)
 					}
 				}(number)
 				taskCount++
 			}
 			parsed := <-parsedCh
-			taskCount, cache[parsed.Number] = taskCount-1, parsed
-			for newHead := types.Long(stats.TotalBlock); cache[newHead] != nil; {
+			if taskCount--; parsed == nil {
+				continue
+			}
+			cache[parsed.Number] = parsed
+			// the context is only checked between blocks, so that the block being written is finished
+			for newHead := types.Long(stats.TotalBlock); cache[newHead] != nil && ctx.Err() == nil; {
 				if head, err := write(client, ctx, cache[newHead]); err != nil {
 					log.Printf("%v block write error: %v\n", newHead, err)
-					time.Sleep(10 * interval)
+					sleep(ctx, 10*interval)
 				} else if head == newHead {
 					delete(cache, newHead)
 					newHead++
@@ -71,5 +160,6 @@ func loop(client *node.Client, ctx context.Context, stats *model.Stats, thread i
 				}
 			}
 		}
+		stats.Ready = true
 	}
 }
@@ -0,0 +1,88 @@
+package backend
+
+import (
+	"context"
+	"log"
+
+	"server/common/model"
+	"server/common/types"
+	"server/common/utils"
+	"server/node"
+	"server/service"
+)
+
+// decodeTokens registers the token contracts created by the block and reads the total supply of the tokens
+// transferred by it, the holder and transfer counts are computed by the service from the stored transfers
+func decodeTokens(c *node.Client, ctx context.Context, parsed *model.Parsed) (err error) {
+	number := parsed.Number.Hex()
+	created := make(map[types.Address]bool)
+	for _, account := range parsed.CacheAccounts {
+		if account.Type == nil || (*account.Type != types.ERC20 && *account.Type != types.ERC721 && *account.Type != types.ERC1155) {
+			continue
+		}
+		// the accounts of the later blocks only carry the type when the contract is created
+		if parsed.Number > 0 && account.CreatedTx == nil {
+			continue
+		}
+		token := &model.Token{
+			Address:      account.Address,
+			Type:         account.Type,
+			Name:         account.Name,
+			Symbol:       account.Symbol,
+			SupplyNumber: parsed.Number,
+			Creator:      account.Creator,
+			CreatedTx:    account.CreatedTx,
+			BlockNumber:  parsed.Number,
+			Timestamp:    parsed.Timestamp,
+		}
+		if err = utils.SetTokenProperty(c, ctx, number, token); err != nil {
+			return
+		}
+		created[token.Address] = true
+		parsed.CacheTokens = append(parsed.CacheTokens, token)
+	}
+	var transferred []*model.Token
+	for _, transferLog := range parsed.CacheTransferLogs {
+		var address types.Address
+		switch transferLog := transferLog.(type) {
+		case *model.ERC20Transfer:
+			address = transferLog.Address
+		case *model.ERC721Transfer:
+			address = transferLog.Address
+		case *model.ERC1155Transfer:
+			address = transferLog.Address
+		}
+		if created[address] {
+			continue
+		}
+		created[address] = true
+		transferred = append(transferred, &model.Token{Address: address, SupplyNumber: parsed.Number})
+	}
+	if err = utils.SetTokenSupplies(c, ctx, number, transferred); err != nil {
+		return
+	}
+	parsed.CacheTokens = append(parsed.CacheTokens, transferred...)
+	return
+}
+
+// registerTokens registers the token contracts of the accounts stored before the tokens were kept, their decimals
+// and total supply are read at the stored head
+func registerTokens(c *node.Client, ctx context.Context, head types.Long) error {
+	for head >= 0 {
+		tokens, err := service.UnregisteredTokens(100)
+		if err != nil || len(tokens) == 0 {
+			return err
+		}
+		for _, token := range tokens {
+			token.SupplyNumber = head
+			if err = utils.SetTokenProperty(c, ctx, head.Hex(), token); err != nil {
+				return err
+			}
+		}
+		if err = service.RegisterTokens(tokens); err != nil {
+			return err
+		}
+		log.Printf("registered %v token contracts of the stored accounts\n", len(tokens))
+	}
+	return nil
+}
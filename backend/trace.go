@@ -0,0 +1,118 @@
+package backend
+
+import (
+	"context"
+	"fmt"
+	"unicode/utf8"
+
+	"server/common/model"
+	"server/common/types"
+	"server/common/utils"
+	"server/node"
+)
+
+// callFrame is a call traced by callTracer, with the calls it made
+type callFrame struct {
+	Type    string        `json:"type"`
+	From    types.Address `json:"from"`
+	To      types.Address `json:"to"`
+	Value   *types.BigInt `json:"value"`
+	Gas     types.Long    `json:"gas"`
+	GasUsed types.Long    `json:"gasUsed"`
+	Input   string        `json:"input"`
+	Output  string        `json:"output"`
+	Error   *string       `json:"error"`
+	Calls   []*callFrame  `json:"calls"`
+}
+
+// traceResult is an element of the debug_traceBlockByHash result
+type traceResult struct {
+	Result *callFrame `json:"result"`
+	Error  *string    `json:"error"`
+}
+
+var callTracer = map[string]any{"tracer": "callTracer"}
+
+// decodeInternalTxs traces the calls of the transactions with callTracer, one debug_traceBlockByHash call for the
+// block, the transactions that cannot be traced with the block are traced one by one with debug_traceTransaction
+func decodeInternalTxs(c *node.Client, ctx context.Context, parsed *model.Parsed) (err error) {
+	if len(parsed.CacheTxs) == 0 {
+		return
+	}
+	var results []*traceResult
+	if err = c.CallContext(ctx, &results, "debug_traceBlockByHash", parsed.Hash, callTracer); err != nil || len(results) != len(parsed.CacheTxs) {
+		results = make([]*traceResult, len(parsed.CacheTxs))
+	}
+	var reqs []node.BatchElem
+	for i, tx := range parsed.CacheTxs {
+		if results[i] == nil || results[i].Result == nil {
+			results[i] = &traceResult{}
+			reqs = append(reqs, node.BatchElem{
+				Method: "debug_traceTransaction",
+				Args:   []any{tx.Hash, callTracer},
+				Result: &results[i].Result,
+			})
+		}
+	}
+	if len(reqs) > 0 {
+		if err = c.BatchCallContext(ctx, reqs); err != nil {
+			return
+		}
+		if err = firstError(reqs); err != nil {
+			return
+		}
+	}
+	for i, tx := range parsed.CacheTxs {
+		root := results[i].Result
+		if root == nil {
+			return fmt.Errorf("no trace of %v", tx.Hash)
+		}
+		if tx.Error = truncate(root.Error); root.Error != nil && len(root.Output) > 2 {
+			tx.RevertData = root.Output
+			tx.RevertReason, tx.PanicCode = utils.DecodeRevert(types.Bytes(root.Output))
+		}
+		parsed.CacheInternalTxs = append(parsed.CacheInternalTxs, flattenCalls(nil, tx, root.Calls, 1, -1)...)
+	}
+	return
+}
+
+// flattenCalls appends the calls of the transaction and the calls they made in call order, the index of a call is its
+// position in the calls of the transaction
+func flattenCalls(internalTxs []*model.InternalTx, tx *model.Transaction, calls []*callFrame, depth, parent types.Long) []*model.InternalTx {
+	for _, call := range calls {
+		internalTx := &model.InternalTx{
+			TxHash:      tx.Hash,
+			BlockNumber: tx.BlockNumber,
+			Index:       types.Long(len(internalTxs)),
+			Depth:       depth,
+			ParentIndex: parent,
+			Op:          call.Type,
+			From:        call.From,
+			To:          call.To,
+			Value:       "0",
+			Gas:         call.Gas,
+			GasUsed:     call.GasUsed,
+			Input:       call.Input,
+			Output:      call.Output,
+			Error:       truncate(call.Error),
+		}
+		if call.Value != nil {
+			internalTx.Value = *call.Value
+		}
+		internalTxs = flattenCalls(append(internalTxs, internalTx), tx, call.Calls, depth+1, internalTx.Index)
+	}
+	return internalTxs
+}
+
+// truncate cuts the error message to the column size, at the start of a character
+func truncate(message *string) *string {
+	if message != nil && len(*message) > 255 {
+		end := 255
+		for end > 0 && !utf8.RuneStart((*message)[end]) {
+			end--
+		}
+		cut := (*message)[:end]
+		return &cut
+	}
+	return message
+}
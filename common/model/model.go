@@ -2,6 +2,7 @@
 package model
 
 import (
+	"encoding/json"
 	"math/big"
 
 	"gorm.io/gorm"
@@ -10,6 +11,7 @@ import (
 
 var Tables = []interface{}{
 	&Stats{},
+	&Migration{},
 	&Block{},
 	&Transaction{},
 	&EventLog{},
@@ -18,6 +20,10 @@ var Tables = []interface{}{
 	&ERC20Transfer{},
 	&ERC721Transfer{},
 	&ERC1155Transfer{},
+	&Token{},
+	&TokenBalance{},
+	&ERC721Token{},
+	&ProxyImplementation{},
 	&Pledge{},
 	&Staker{},
 	&Slashing{},
@@ -29,10 +35,29 @@ var Tables = []interface{}{
 	&Erbie{},
 	&Reward{},
 	&Location{},
+	&Reorg{},
+	&OrphanedTx{},
 }
 
-func Migrate(db *gorm.DB) error {
-	return db.AutoMigrate(Tables...)
+// KeyTables keep the API keys, they are not cleared with the indexed data
+var KeyTables = []interface{}{
+	&APIKey{},
+	&APIKeyUsage{},
+}
+
+// KeptTables keep the uploaded contract ABIs and the known signatures, they are not cleared with the indexed data
+var KeptTables = []interface{}{
+	&ContractABI{},
+	&Signature{},
+}
+
+func Migrate(db *gorm.DB) (err error) {
+	for _, tables := range [][]interface{}{Tables, KeptTables, KeyTables} {
+		if err = db.AutoMigrate(tables...); err != nil {
+			return
+		}
+	}
+	return
 }
 
 func ClearTable(db *gorm.DB) (err error) {
@@ -45,6 +70,7 @@ func ClearTable(db *gorm.DB) (err error) {
 // Stats caches some database queries to speed up queries
 type Stats struct {
 	Ready                bool   `json:"ready" gorm:"-"`                        //ready, sync latest block
+	ChainHead            int64  `json:"chainHead" gorm:"-"`                    //latest block number of the chain node
 	ChainId              int64  `json:"chainId" gorm:"primaryKey"`             //chain id
 	GenesisBalance       string `json:"genesisBalance" gorm:"type:CHAR(128)"`  //Total amount of coins created
 	TotalAmount          string `json:"totalAmount" gorm:"type:CHAR(128)"`     //total transaction volume
@@ -86,25 +112,32 @@ type Stats struct {
 	Balances map[types.Address]*big.Int `json:"-" gorm:"-"`
 }
 
+// Migration a migration of the stored data that has been done
+type Migration struct {
+	Name      string     `json:"name" gorm:"type:VARCHAR(66);primaryKey"` //migration name
+	Timestamp types.Long `json:"timestamp"`                               //time it was done
+}
+
 // Header block header information
 type Header struct {
-	Difficulty       types.Long    `json:"difficulty"`                              //difficulty
-	ExtraData        string        `json:"extraData"`                               //Extra data
-	GasLimit         types.Long    `json:"gasLimit"`                                //Gas limit
-	GasUsed          types.Long    `json:"gasUsed"`                                 //Gas consumption
-	Hash             types.Hash    `json:"hash" gorm:"type:CHAR(66);primaryKey"`    //Hash
-	Miner            types.Address `json:"miner" gorm:"type:CHAR(42);index"`        //miner
-	MixHash          types.Hash    `json:"mixHash" gorm:"type:CHAR(66)"`            //Mixed hash
-	Nonce            types.Bytes8  `json:"nonce" gorm:"type:CHAR(18)"`              //difficulty random number
-	Number           types.Long    `json:"number" gorm:"index"`                     //block number
-	ParentHash       types.Hash    `json:"parentHash" gorm:"type:CHAR(66)"`         //parent block hash
-	ReceiptsRoot     types.Hash    `json:"receiptsRoot" gorm:"type:CHAR(66)"`       //Transaction receipt root hash
-	Sha3Uncles       types.Hash    `json:"sha3Uncles" gorm:"type:CHAR(66)"`         //Uncle root hash
-	Size             types.Long    `json:"size"`                                    //size
-	StateRoot        types.Hash    `json:"stateRoot" gorm:"type:CHAR(66)"`          //World tree root hash
-	Timestamp        types.Long    `json:"timestamp" gorm:"index"`                  //timestamp
-	TotalDifficulty  types.BigInt  `json:"totalDifficulty" gorm:"type:DECIMAL(65)"` //total difficulty
-	TransactionsRoot types.Hash    `json:"transactionsRoot" gorm:"type:CHAR(66)"`   //transaction root hash
+	Difficulty       types.Long    `json:"difficulty"`                                      //difficulty
+	ExtraData        string        `json:"extraData"`                                       //Extra data
+	GasLimit         types.Long    `json:"gasLimit"`                                        //Gas limit
+	GasUsed          types.Long    `json:"gasUsed"`                                         //Gas consumption
+	Hash             types.Hash    `json:"hash" gorm:"type:CHAR(66);primaryKey"`            //Hash
+	Miner            types.Address `json:"miner" gorm:"type:CHAR(42);index"`                //miner
+	MixHash          types.Hash    `json:"mixHash" gorm:"type:CHAR(66)"`                    //Mixed hash
+	Nonce            types.Bytes8  `json:"nonce" gorm:"type:CHAR(18)"`                      //difficulty random number
+	Number           types.Long    `json:"number" gorm:"index"`                             //block number
+	ParentHash       types.Hash    `json:"parentHash" gorm:"type:CHAR(66)"`                 //parent block hash
+	ReceiptsRoot     types.Hash    `json:"receiptsRoot" gorm:"type:CHAR(66)"`               //Transaction receipt root hash
+	Sha3Uncles       types.Hash    `json:"sha3Uncles" gorm:"type:CHAR(66)"`                 //Uncle root hash
+	Size             types.Long    `json:"size"`                                            //size
+	StateRoot        types.Hash    `json:"stateRoot" gorm:"type:CHAR(66)"`                  //World tree root hash
+	Timestamp        types.Long    `json:"timestamp" gorm:"index"`                          //timestamp
+	TotalDifficulty  types.BigInt  `json:"totalDifficulty" gorm:"type:DECIMAL(65)"`         //total difficulty
+	TransactionsRoot types.Hash    `json:"transactionsRoot" gorm:"type:CHAR(66)"`           //transaction root hash
+	BaseFeePerGas    *types.BigInt `json:"baseFeePerGas,omitempty" gorm:"type:DECIMAL(65)"` //EIP-1559 base fee, unit wei, empty before london
 }
 
 // Block information
@@ -112,8 +145,32 @@ type Block struct {
 	Header
 	Uncles           []types.Hash    `json:"uncles" gorm:"type:VARCHAR(139);serializer:json"` //Uncle block hash
 	TotalTransaction types.Long      `json:"totalTransaction"`                                //number of transactions
+	TotalFees        types.BigInt    `json:"totalFees" gorm:"type:DECIMAL(65)"`               //sum of the transaction fees, unit wei
+	BurntFees        types.BigInt    `json:"burntFees" gorm:"type:DECIMAL(65)"`               //base fee times the gas used, unit wei
 	Proposers        []types.Address `json:"proposers,omitempty" gorm:"serializer:json"`      //black hole block proposers address
 	Proof            []types.Hash    `json:"proof,omitempty" gorm:"index;serializer:json"`    //slash validator proof, multi-signature block hash
+	Confirmations    int64           `json:"confirmations" gorm:"-"`                          //number of blocks from this block to the chain head, both included
+	Finalized        bool            `json:"finalized" gorm:"-"`                              //whether the block has the confirmations required to be final
+}
+
+// Reorg chain reorganization, the stored blocks after the fork block were replaced by the blocks of another chain
+type Reorg struct {
+	ID         int64        `json:"id" gorm:"primaryKey"`                    //reorg id
+	ForkNumber types.Long   `json:"forkNumber" gorm:"index"`                 //number of the last block common to both chains
+	OldHead    types.Long   `json:"oldHead"`                                 //number of the stored head before the reorg
+	OldHash    types.Hash   `json:"oldHash" gorm:"type:CHAR(66)"`            //hash of the stored head before the reorg
+	NewHead    types.Long   `json:"newHead"`                                 //number of the block of the new chain that revealed the reorg
+	NewHash    types.Hash   `json:"newHash" gorm:"type:CHAR(66)"`            //hash of the block of the new chain that revealed the reorg
+	Depth      types.Long   `json:"depth"`                                   //number of orphaned blocks
+	Blocks     []types.Hash `json:"blocks" gorm:"type:TEXT;serializer:json"` //orphaned block hashes, in height order
+	TxCount    int64        `json:"txCount"`                                 //number of orphaned transactions
+	Timestamp  types.Long   `json:"timestamp" gorm:"index"`                  //time the reorg was found
+}
+
+// OrphanedTx transaction of an orphaned block, it may be included again by a block of the new chain
+type OrphanedTx struct {
+	ReorgID int64 `json:"reorgId" gorm:"primaryKey"` //reorg id
+	Transaction
 }
 
 // Slashing validator and staker penalty record
@@ -127,92 +184,195 @@ type Slashing struct {
 
 // Account information
 type Account struct {
-	Address   types.Address       `json:"address" gorm:"type:CHAR(42);primaryKey"`  //address
-	Balance   types.BigInt        `json:"balance" gorm:"type:DECIMAL(65);index"`    //The total amount of coins in the chain
-	Nonce     types.Long          `json:"nonce"`                                    //transaction random number, transaction volume
-	Code      *string             `json:"code"`                                     //bytecode
-	Number    types.Long          `json:"number" gorm:"index"`                      //last update block number
-	Name      *string             `json:"name,omitempty" gorm:"type:VARCHAR(66)"`   //name
-	Symbol    *string             `json:"symbol,omitempty" gorm:"type:VARCHAR(66)"` //symbol
-	Type      *types.ContractType `json:"type,omitempty"`                           //contract types, ERC20, ERC721, ERC1155
-	Creator   *types.Address      `json:"creator,omitempty" gorm:"type:CHAR(42)"`   //the creator, the contract account has value
-	CreatedTx *types.Hash         `json:"createdTx,omitempty" gorm:"type:CHAR(66)"` //create transaction
-	SNFTCount int64               `json:"snftCount"`                                //hold SNFT number
-	SNFTValue string              `json:"snftValue" gorm:"type:DECIMAL(65)"`        //hold SNFT value
-	NFTCount  int64               `json:"nftCount"`                                 //hold NFT number
-	Timestamp types.Long          `json:"timestamp"`                                //The event stamp of the account it is in
+	Address   types.Address       `json:"address" gorm:"type:CHAR(42);primaryKey"`        //address
+	Balance   types.BigInt        `json:"balance" gorm:"type:DECIMAL(65);index"`          //The total amount of coins in the chain
+	Nonce     types.Long          `json:"nonce"`                                          //transaction random number, transaction volume
+	Code      *string             `json:"code"`                                           //bytecode
+	Number    types.Long          `json:"number" gorm:"index"`                            //last update block number
+	Name      *string             `json:"name,omitempty" gorm:"type:VARCHAR(66);index"`   //name
+	Symbol    *string             `json:"symbol,omitempty" gorm:"type:VARCHAR(66);index"` //symbol
+	Type      *types.ContractType `json:"type,omitempty"`                                 //contract types, ERC20, ERC721, ERC1155
+	Creator   *types.Address      `json:"creator,omitempty" gorm:"type:CHAR(42)"`         //the creator, the contract account has value
+	CreatedTx *types.Hash         `json:"createdTx,omitempty" gorm:"type:CHAR(66)"`       //create transaction
+	SNFTCount int64               `json:"snftCount"`                                      //hold SNFT number
+	SNFTValue string              `json:"snftValue" gorm:"type:DECIMAL(65)"`              //hold SNFT value
+	NFTCount  int64               `json:"nftCount"`                                       //hold NFT number
+	Timestamp types.Long          `json:"timestamp"`                                      //The event stamp of the account it is in
 }
 
 // Transaction information
 type Transaction struct {
-	BlockHash         types.Hash     `json:"blockHash" gorm:"type:CHAR(66)"`          //Block Hash
-	BlockNumber       types.Long     `json:"blockNumber" gorm:"index"`                //block number
-	Timestamp         types.Long     `json:"timestamp"`                               //The event stamp of the block it is in
-	From              types.Address  `json:"from" gorm:"type:CHAR(42);index"`         //Send address
-	To                *types.Address `json:"to" gorm:"type:CHAR(42);index"`           //Receive address
-	Input             string         `json:"input" gorm:"type:TEXT"`                  //Additional input data, contract call encoded data
-	Value             types.BigInt   `json:"value" gorm:"type:DECIMAL(65)"`           //Amount, unit wei
-	Nonce             types.Long     `json:"nonce"`                                   //Random number, the number of transactions initiated by the account
-	Gas               types.Long     `json:"gas"`                                     //fuel
-	GasPrice          types.Long     `json:"gasPrice"`                                //Gas price
-	Hash              types.Hash     `json:"hash" gorm:"type:CHAR(66);primaryKey"`    //Hash
-	Status            *types.Long    `json:"status,omitempty"`                        //Status, 1: success; 0: failure
-	CumulativeGasUsed types.Long     `json:"cumulativeGasUsed"`                       //Cumulative gas consumption
-	ContractAddress   *types.Address `json:"contractAddress" gorm:"type:CHAR(42)"`    //The created contract address
-	GasUsed           types.Long     `json:"gasUsed"`                                 //Gas consumption
-	TxIndex           types.Long     `json:"transactionIndex"`                        //The serial number in the block
-	Error             *string        `json:"error,omitempty" gorm:"type:VARCHAR(66)"` //exec error
+	BlockHash            types.Hash     `json:"blockHash" gorm:"type:CHAR(66)"`                         //Block Hash
+	BlockNumber          types.Long     `json:"blockNumber" gorm:"index"`                               //block number
+	Timestamp            types.Long     `json:"timestamp"`                                              //The event stamp of the block it is in
+	From                 types.Address  `json:"from" gorm:"type:CHAR(42);index"`                        //Send address
+	To                   *types.Address `json:"to" gorm:"type:CHAR(42);index"`                          //Receive address
+	Input                string         `json:"input" gorm:"type:TEXT"`                                 //Additional input data, contract call encoded data
+	Value                types.BigInt   `json:"value" gorm:"type:DECIMAL(65)"`                          //Amount, unit wei
+	Nonce                types.Long     `json:"nonce"`                                                  //Random number, the number of transactions initiated by the account
+	Gas                  types.Long     `json:"gas"`                                                    //fuel
+	GasPrice             types.BigInt   `json:"gasPrice" gorm:"type:DECIMAL(65)"`                       //Gas price, the fee cap for EIP-1559 transactions
+	Type                 types.Long     `json:"type"`                                                   //transaction type, 0: legacy; 1: EIP-2930; 2: EIP-1559
+	MaxFeePerGas         *types.BigInt  `json:"maxFeePerGas,omitempty" gorm:"type:DECIMAL(65)"`         //EIP-1559 fee cap, unit wei
+	MaxPriorityFeePerGas *types.BigInt  `json:"maxPriorityFeePerGas,omitempty" gorm:"type:DECIMAL(65)"` //EIP-1559 tip cap, unit wei
+	EffectiveGasPrice    *types.BigInt  `json:"effectiveGasPrice,omitempty" gorm:"type:DECIMAL(65)"`    //gas price actually paid, from the receipt
+	TxFee                types.BigInt   `json:"txFee" gorm:"type:DECIMAL(65)"`                          //gas used times the effective gas price, unit wei
+	AccessList           []AccessTuple  `json:"accessList,omitempty" gorm:"type:TEXT;serializer:json"`  //EIP-2930 access list
+	ChainId              *types.Long    `json:"chainId,omitempty"`                                      //chain id, empty for legacy transactions before EIP-155
+	V                    types.Bytes    `json:"v" gorm:"type:VARCHAR(66)"`                              //signature V, y parity for typed transactions
+	R                    types.Bytes    `json:"r" gorm:"type:VARCHAR(66)"`                              //signature R
+	S                    types.Bytes    `json:"s" gorm:"type:VARCHAR(66)"`                              //signature S
+	Hash                 types.Hash     `json:"hash" gorm:"type:CHAR(66);primaryKey"`                   //Hash
+	Status               *types.Long    `json:"status,omitempty"`                                       //Status, 1: success; 0: failure
+	CumulativeGasUsed    types.Long     `json:"cumulativeGasUsed"`                                      //Cumulative gas consumption
+	ContractAddress      *types.Address `json:"contractAddress" gorm:"type:CHAR(42)"`                   //The created contract address
+	GasUsed              types.Long     `json:"gasUsed"`                                                //Gas consumption
+	TxIndex              types.Long     `json:"transactionIndex"`                                       //The serial number in the block
+	Error                *string        `json:"error,omitempty" gorm:"type:VARCHAR(255)"`               //exec error
+	RevertData           string         `json:"revertData,omitempty" gorm:"type:TEXT"`                  //return data of the failed transaction
+	RevertReason         *string        `json:"revertReason,omitempty" gorm:"type:TEXT"`                //decoded revert reason, Error(string), panic description or custom error
+	PanicCode            *types.Long    `json:"panicCode,omitempty"`                                    //Panic(uint256) code
+	Confirmations        int64          `json:"confirmations" gorm:"-"`                                 //number of blocks from the block of the transaction to the chain head, both included
+	Finalized            bool           `json:"finalized" gorm:"-"`                                     //whether the block of the transaction has the confirmations required to be final
+	Method               *string        `json:"method,omitempty" gorm:"-"`                              //text signature of the called function, best effort from the known selectors
+	MethodCandidates     []string       `json:"methodCandidates,omitempty" gorm:"-"`                    //all the known text signatures when the selector is ambiguous
+}
+
+// AccessTuple address and storage keys of an access list
+type AccessTuple struct {
+	Address     types.Address `json:"address"`     //accessed address
+	StorageKeys []types.Hash  `json:"storageKeys"` //accessed storage keys
 }
 
 // EventLog transaction log
 type EventLog struct {
-	Address     types.Address `json:"address" gorm:"type:CHAR(42)"`                          //The contract address
-	Topics      []types.Hash  `json:"topics" gorm:"type:VARCHAR(277);serializer:json"`       //topic
-	Data        string        `json:"data"`                                                  //data
-	Removed     bool          `json:"removed"`                                               //whether to remove
-	BlockNumber types.Long    `json:"blockNumber"`                                           //block number
-	TxHash      types.Hash    `json:"transactionHash" gorm:"type:CHAR(66);primaryKey;index"` //The transaction hash
-	Index       types.Long    `json:"logIndex" gorm:"primaryKey"`                            //The serial number in the transaction
+	Address         types.Address `json:"address" gorm:"type:CHAR(42)"`                          //The contract address
+	Topics          []types.Hash  `json:"topics" gorm:"type:VARCHAR(277);serializer:json"`       //topic
+	Data            string        `json:"data"`                                                  //data
+	Removed         bool          `json:"removed"`                                               //whether to remove
+	BlockNumber     types.Long    `json:"blockNumber"`                                           //block number
+	TxHash          types.Hash    `json:"transactionHash" gorm:"type:CHAR(66);primaryKey;index"` //The transaction hash
+	Index           types.Long    `json:"logIndex" gorm:"primaryKey"`                            //The serial number in the transaction
+	Event           *string       `json:"event,omitempty" gorm:"-"`                              //text signature of the event, best effort from the known topics
+	EventCandidates []string      `json:"eventCandidates,omitempty" gorm:"-"`                    //all the known text signatures when the topic is ambiguous
 }
 
 // InternalTx internal transaction
 type InternalTx struct {
-	TxHash types.Hash    `json:"txHash" gorm:"type:CHAR(66);index"` //The transaction
-	Index  types.Long    `json:"index" gorm:"not null"`             //call index
-	Op     string        `json:"op" gorm:"type:VARCHAR(16)"`        //Operation
-	From   types.Address `json:"from" gorm:"type:CHAR(42);index"`   //Originating address
-	To     types.Address `json:"to" gorm:"type:CHAR(42);index"`     //Receive address
-	Value  types.BigInt  `json:"value" gorm:"type:DECIMAL(65)"`     //Amount, unit wei
-	Gas    types.Long    `json:"gas"`                               //Gas
+	TxHash      types.Hash    `json:"txHash" gorm:"type:CHAR(66);index"`        //The transaction
+	BlockNumber types.Long    `json:"blockNumber" gorm:"index"`                 //block number
+	Index       types.Long    `json:"index" gorm:"not null"`                    //call index, in call order
+	Depth       types.Long    `json:"depth"`                                    //call depth, 1: called by the transaction
+	ParentIndex types.Long    `json:"parentIndex"`                              //index of the calling call, -1: the transaction
+	Op          string        `json:"op" gorm:"type:VARCHAR(16)"`               //Operation
+	From        types.Address `json:"from" gorm:"type:CHAR(42);index"`          //Originating address
+	To          types.Address `json:"to" gorm:"type:CHAR(42);index"`            //Receive address
+	Value       types.BigInt  `json:"value" gorm:"type:DECIMAL(65)"`            //Amount, unit wei
+	Gas         types.Long    `json:"gas"`                                      //Gas
+	GasUsed     types.Long    `json:"gasUsed"`                                  //Gas consumption
+	Input       string        `json:"input" gorm:"type:TEXT"`                   //call data
+	Output      string        `json:"output" gorm:"type:TEXT"`                  //return data
+	Error       *string       `json:"error,omitempty" gorm:"type:VARCHAR(255)"` //exec error
+}
+
+// Token ERC20, ERC721 or ERC1155 token contract
+type Token struct {
+	Address       types.Address       `json:"address" gorm:"type:CHAR(42);primaryKey"`             //contract address
+	Type          *types.ContractType `json:"type" gorm:"index"`                                   //contract type, ERC20, ERC721, ERC1155
+	Name          *string             `json:"name,omitempty" gorm:"type:VARCHAR(66)"`              //name
+	Symbol        *string             `json:"symbol,omitempty" gorm:"type:VARCHAR(66)"`            //symbol
+	Decimals      *types.Long         `json:"decimals,omitempty"`                                  //ERC20 decimals, empty when not implemented
+	TotalSupply   *types.BigInt       `json:"totalSupply,omitempty" gorm:"type:VARCHAR(80);index"` //total supply, empty when not implemented
+	SupplyNumber  types.Long          `json:"supplyNumber"`                                        //block number the total supply was read at
+	HolderCount   int64               `json:"holderCount" gorm:"index"`                            //number of addresses holding the token
+	TransferCount int64               `json:"transferCount" gorm:"index"`                          //number of transfer events
+	Creator       *types.Address      `json:"creator,omitempty" gorm:"type:CHAR(42)"`              //the creator, empty for the genesis contracts
+	CreatedTx     *types.Hash         `json:"createdTx,omitempty" gorm:"type:CHAR(66)"`            //create transaction
+	BlockNumber   types.Long          `json:"blockNumber" gorm:"index"`                            //created block number
+	Timestamp     types.Long          `json:"timestamp"`                                           //created timestamp
+}
+
+// TokenBalance token balance of a holder, summed from the transfer events
+type TokenBalance struct {
+	Address types.Address `json:"address" gorm:"type:CHAR(42);primaryKey"`              //token contract address
+	Holder  types.Address `json:"holder" gorm:"type:CHAR(42);primaryKey;index"`         //holder address
+	TokenId types.BigInt  `json:"tokenId,omitempty" gorm:"type:VARCHAR(80);primaryKey"` //ERC1155 token ID, empty for ERC20 and ERC721
+	Balance types.BigInt  `json:"balance" gorm:"type:VARCHAR(80);index"`                //balance, the number of tokens for ERC721
+}
+
+// ERC721Token current owner of an ERC721 token
+type ERC721Token struct {
+	Address     types.Address `json:"address" gorm:"type:CHAR(42);primaryKey"`    //token contract address
+	TokenId     types.BigInt  `json:"tokenId" gorm:"type:VARCHAR(80);primaryKey"` //Token ID
+	Owner       types.Address `json:"owner" gorm:"type:CHAR(42);index"`           //owner, the zero address when burnt
+	BlockNumber types.Long    `json:"blockNumber" gorm:"index"`                   //block number of the last transfer
+	TxHash      types.Hash    `json:"txHash" gorm:"type:CHAR(66)"`                //transaction hash of the last transfer
+}
+
+// ContractABI ABI of a contract, uploaded to decode its transactions and event logs
+type ContractABI struct {
+	Address   types.Address   `json:"address" gorm:"type:CHAR(42);primaryKey"`             //contract address
+	Name      *string         `json:"name,omitempty" gorm:"type:VARCHAR(66)"`              //contract name
+	ABI       json.RawMessage `json:"abi" gorm:"size:16777215" swaggertype:"array,object"` //ABI JSON
+	Timestamp types.Long      `json:"timestamp"`                                           //upload time
+}
+
+// ProxyImplementation implementation of a proxy contract from the block on, one row per creation or upgrade
+type ProxyImplementation struct {
+	Address        types.Address  `json:"address" gorm:"type:CHAR(42);primaryKey"`     //proxy contract, or upgradeable beacon
+	BlockNumber    types.Long     `json:"blockNumber" gorm:"primaryKey;index"`         //block of the creation or the upgrade
+	Kind           string         `json:"kind" gorm:"type:VARCHAR(16)"`                //eip1967, eip1967-beacon, eip1822, eip1167, or beacon for the upgradeable beacon
+	Implementation types.Address  `json:"implementation" gorm:"type:CHAR(42);index"`   //implementation contract
+	Beacon         *types.Address `json:"beacon,omitempty" gorm:"type:CHAR(42);index"` //beacon of the eip1967-beacon proxy
+	TxHash         *types.Hash    `json:"txHash,omitempty" gorm:"type:CHAR(66)"`       //creation or upgrade transaction
+	Timestamp      types.Long     `json:"timestamp"`                                   //block time
+}
+
+// Signature text signature of a function or an event added to the built-in signature database
+type Signature struct {
+	Kind      string     `json:"kind" gorm:"type:VARCHAR(8);primaryKey"`        //function or event
+	Signature string     `json:"signature" gorm:"type:VARCHAR(255);primaryKey"` //canonical text signature, e.g. transfer(address,uint256)
+	Hash      string     `json:"hash" gorm:"type:VARCHAR(66);index"`            //4 bytes selector of the function or topic of the event
+	Timestamp types.Long `json:"timestamp"`                                     //upload time
 }
 
 // ERC20Transfer ERC20 contract transfer event
 type ERC20Transfer struct {
-	TxHash  types.Hash    `json:"txHash" gorm:"type:CHAR(66);index"`  //The transaction hash
-	Address types.Address `json:"address" gorm:"type:CHAR(42);index"` //Contract address
-	From    types.Address `json:"from" gorm:"type:CHAR(42);index"`    //Originating address
-	To      types.Address `json:"to" gorm:"type:CHAR(42);index"`      //Receive address
-	Value   types.BigInt  `json:"value" gorm:"type:VARCHAR(80)"`      //amount
+	TxHash      types.Hash    `json:"txHash" gorm:"type:CHAR(66);index"`  //The transaction hash
+	BlockNumber types.Long    `json:"blockNumber" gorm:"index"`           //block number
+	LogIndex    types.Long    `json:"logIndex" gorm:"index"`              //The serial number of the log in the block
+	Timestamp   types.Long    `json:"timestamp"`                          //The event stamp of the block it is in
+	Address     types.Address `json:"address" gorm:"type:CHAR(42);index"` //Contract address
+	From        types.Address `json:"from" gorm:"type:CHAR(42);index"`    //Originating address
+	To          types.Address `json:"to" gorm:"type:CHAR(42);index"`      //Receive address
+	Value       types.BigInt  `json:"value" gorm:"type:VARCHAR(80)"`      //amount
 }
 
 // ERC721Transfer ERC721 contract transfer event
 type ERC721Transfer struct {
-	TxHash  types.Hash    `json:"txHash" gorm:"type:CHAR(66);index"` //The transaction hash
-	Address types.Address `json:"address" gorm:"type:CHAR(42)"`      //Contract address
-	From    types.Address `json:"from" gorm:"type:CHAR(42);index"`   //Originating address
-	To      types.Address `json:"to" gorm:"type:CHAR(42);index"`     //Receive address
-	TokenId types.BigInt  `json:"tokenId" gorm:"type:VARCHAR(80)"`   //Token ID
+	TxHash      types.Hash    `json:"txHash" gorm:"type:CHAR(66);index"`  //The transaction hash
+	BlockNumber types.Long    `json:"blockNumber" gorm:"index"`           //block number
+	LogIndex    types.Long    `json:"logIndex" gorm:"index"`              //The serial number of the log in the block
+	Timestamp   types.Long    `json:"timestamp"`                          //The event stamp of the block it is in
+	Address     types.Address `json:"address" gorm:"type:CHAR(42);index"` //Contract address
+	From        types.Address `json:"from" gorm:"type:CHAR(42);index"`    //Originating address
+	To          types.Address `json:"to" gorm:"type:CHAR(42);index"`      //Receive address
+	TokenId     types.BigInt  `json:"tokenId" gorm:"type:VARCHAR(80)"`    //Token ID
 }
 
 // ERC1155Transfer ERC1155 contract transfer event
 type ERC1155Transfer struct {
-	TxHash   types.Hash    `json:"txHash" gorm:"type:CHAR(66);index"` //The transaction hash
-	Address  types.Address `json:"address" gorm:"type:CHAR(42)"`      //Contract address
-	Operator types.Address `json:"operator" gorm:"type:CHAR(42)"`     //operator address
-	From     types.Address `json:"from" gorm:"type:CHAR(42);index"`   //Originating address
-	To       types.Address `json:"to" gorm:"type:CHAR(42);index"`     //Receive address
-	TokenId  types.BigInt  `json:"tokenId" gorm:"type:VARCHAR(80)"`   //Token ID
-	Value    types.BigInt  `json:"value" gorm:"type:VARCHAR(80)"`     //Token amount
+	TxHash      types.Hash    `json:"txHash" gorm:"type:CHAR(66);index"`  //The transaction hash
+	BlockNumber types.Long    `json:"blockNumber" gorm:"index"`           //block number
+	LogIndex    types.Long    `json:"logIndex" gorm:"index"`              //The serial number of the log in the block
+	BatchIndex  types.Long    `json:"batchIndex"`                         //The position in the TransferBatch event, 0 for TransferSingle
+	Timestamp   types.Long    `json:"timestamp"`                          //The event stamp of the block it is in
+	Address     types.Address `json:"address" gorm:"type:CHAR(42);index"` //Contract address
+	Operator    types.Address `json:"operator" gorm:"type:CHAR(42)"`      //operator address
+	From        types.Address `json:"from" gorm:"type:CHAR(42);index"`    //Originating address
+	To          types.Address `json:"to" gorm:"type:CHAR(42);index"`      //Receive address
+	TokenId     types.BigInt  `json:"tokenId" gorm:"type:VARCHAR(80)"`    //Token ID
+	Value       types.BigInt  `json:"value" gorm:"type:VARCHAR(80)"`      //Token amount
 }
 
 // NFT User NFT attribute information
@@ -335,6 +495,36 @@ type Validator struct {
 	Score        int64  `json:"score"`                                   //node comprehensive score
 }
 
+// API key roles, a role may use the routes of the roles before it
+const (
+	RolePublic  = "public"
+	RolePartner = "partner"
+	RoleAdmin   = "admin"
+)
+
+// Roles the API key roles from the lowest to the highest
+var Roles = []string{RolePublic, RolePartner, RoleAdmin}
+
+// APIKey key of an API client, sent in the X-API-Key header, only the SHA-256 hash of the key is stored
+type APIKey struct {
+	ID        int64       `json:"id" gorm:"primaryKey"`               //key id
+	Name      string      `json:"name" gorm:"type:VARCHAR(66)"`       //client name
+	Prefix    string      `json:"prefix" gorm:"type:CHAR(8)"`         //first characters of the key, to tell the keys apart
+	Hash      string      `json:"-" gorm:"type:CHAR(64);uniqueIndex"` //SHA-256 hash of the key, hex
+	Role      string      `json:"role" gorm:"type:VARCHAR(16)"`       //public, partner or admin
+	Timestamp types.Long  `json:"timestamp"`                          //issue time
+	RevokedAt *types.Long `json:"revokedAt,omitempty"`                //revoke time, empty while the key is valid
+	LastUsed  *types.Long `json:"lastUsed,omitempty"`                 //time of the last request, recorded every few seconds
+	Requests  int64       `json:"requests"`                           //number of requests made with the key
+}
+
+// APIKeyUsage number of requests made with an API key in a day
+type APIKeyUsage struct {
+	KeyID    int64  `json:"keyId" gorm:"primaryKey"`             //key id
+	Day      string `json:"day" gorm:"type:CHAR(10);primaryKey"` //local date, YYYY-MM-DD
+	Requests int64  `json:"requests"`                            //number of requests
+}
+
 type Location struct {
 	Address   string  `json:"address" gorm:"type:CHAR(42);primaryKey"` //account address
 	IP        string  `json:"ip" gorm:"type:VARCHAR(15)"`              //account ip
@@ -352,6 +542,8 @@ type Parsed struct {
 	CacheTransferLogs []interface{}
 	CacheAccounts     []*Account
 	CacheLogs         []*EventLog
+	CacheTokens       []*Token               //tokens created by the block, or only the total supply of the tokens it transferred
+	CacheProxies      []*ProxyImplementation //implementations of the proxies created or upgraded by the block
 
 	// erbie, which need to be inserted into the database by priority (later data may query previous data)
 	Epoch     *Epoch      //Official injection of the first phase of SNFT
@@ -0,0 +1,605 @@
+package utils
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"server/common/types"
+)
+
+// ABIArgument input or output of a contract ABI entry
+type ABIArgument struct {
+	Name       string        `json:"name"`                 //argument name, may be empty
+	Type       string        `json:"type"`                 //solidity type, e.g. uint256, address[], tuple
+	Indexed    bool          `json:"indexed,omitempty"`    //whether the event argument is a topic
+	Components []ABIArgument `json:"components,omitempty"` //fields of the tuple type
+}
+
+// ABIEntry function, event or error of a contract ABI
+type ABIEntry struct {
+	Type            string        `json:"type"`                      //function, event, error, constructor, fallback or receive
+	Name            string        `json:"name,omitempty"`            //name
+	Inputs          []ABIArgument `json:"inputs,omitempty"`          //arguments, event parameters
+	Outputs         []ABIArgument `json:"outputs,omitempty"`         //function return values
+	Anonymous       bool          `json:"anonymous,omitempty"`       //anonymous event, without the signature topic
+	StateMutability string        `json:"stateMutability,omitempty"` //pure, view, nonpayable or payable
+}
+
+// ABI contract ABI, the JSON interface description generated by the compilers
+type ABI []*ABIEntry
+
+// ABIValue decoded argument
+type ABIValue struct {
+	Name  string `json:"name"`  //argument name, may be empty
+	Type  string `json:"type"`  //solidity type
+	Value any    `json:"value"` //numbers as decimal strings, bytes as hex, arrays as lists, tuples as lists of ABIValue
+}
+
+// ABIDecoded decoded contract call, event log or error
+type ABIDecoded struct {
+	Name      string      `json:"name"`      //function, event or error name
+	Signature string      `json:"signature"` //canonical signature, e.g. transfer(address,uint256)
+	Args      []*ABIValue `json:"args"`      //decoded arguments
+}
+
+// ParseABI parses the JSON of a contract ABI and checks the types of all its entries
+func ParseABI(data []byte) (abi ABI, err error) {
+	if err = json.Unmarshal(data, &abi); err != nil {
+		return nil, err
+	}
+	for _, entry := range abi {
+		if entry == nil {
+			return nil, errors.New("empty ABI entry")
+		}
+		for _, args := range [][]ABIArgument{entry.Inputs, entry.Outputs} {
+			if _, err = newABITuple(args); err != nil {
+				return nil, fmt.Errorf("%s %s: %v", entry.Type, entry.Name, err)
+			}
+		}
+	}
+	return
+}
+
+// Signature returns the canonical signature of the entry, e.g. Transfer(address,address,uint256)
+func (e *ABIEntry) Signature() string {
+	params := make([]string, len(e.Inputs))
+	for i, arg := range e.Inputs {
+		params[i] = arg.canonical()
+	}
+	return e.Name + "(" + strings.Join(params, ",") + ")"
+}
+
+// Selector returns the 4 bytes selector of the function or error, with the 0x prefix
+func (e *ABIEntry) Selector() string {
+	return "0x" + string(Keccak256Hash([]byte(e.Signature())))[:8]
+}
+
+// Topic returns the signature topic of the event
+func (e *ABIEntry) Topic() types.Hash {
+	return "0x" + Keccak256Hash([]byte(e.Signature()))
+}
+
+// Function finds the function by name or text signature, the overloaded functions of the name are told apart by
+// the number of arguments
+func (a ABI) Function(name string, argc int) (*ABIEntry, error) {
+	signature := ""
+	if strings.Contains(name, "(") {
+		var err error
+		if signature, err = ParseSignature(name); err != nil {
+			return nil, err
+		}
+	}
+	var found []*ABIEntry
+	for _, entry := range a {
+		if entry.Type != "function" {
+			continue
+		}
+		if signature != "" && entry.Signature() == signature || signature == "" && entry.Name == name && len(entry.Inputs) == argc {
+			found = append(found, entry)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("unknown function %s with %d arguments", name, argc)
+	case 1:
+		return found[0], nil
+	}
+	return nil, fmt.Errorf("ambiguous function %s, use the signature", name)
+}
+
+// DecodeInput decodes the call data of a transaction with the function of the ABI it calls
+func (a ABI) DecodeInput(input string) (*ABIDecoded, error) {
+	data, err := hexToBytes(input)
+	if err != nil || len(data) < 4 {
+		return nil, errors.New("no function selector")
+	}
+	selector := "0x" + hex.EncodeToString(data[:4])
+	for _, entry := range a {
+		if entry.Type == "function" && entry.Selector() == selector {
+			return entry.decode(entry.Inputs, data[4:])
+		}
+	}
+	return nil, fmt.Errorf("unknown function %s", selector)
+}
+
+// DecodeError decodes the revert data of a call with the custom error of the ABI it returns
+func (a ABI) DecodeError(output string) (*ABIDecoded, error) {
+	data, err := hexToBytes(output)
+	if err != nil || len(data) < 4 {
+		return nil, errors.New("no error selector")
+	}
+	selector := "0x" + hex.EncodeToString(data[:4])
+	for _, entry := range a {
+		if entry.Type == "error" && entry.Selector() == selector {
+			return entry.decode(entry.Inputs, data[4:])
+		}
+	}
+	return nil, fmt.Errorf("unknown error %s", selector)
+}
+
+// DecodeLog decodes the topics and data of an event log with the event of the ABI it was emitted by,
+// the indexed arguments of dynamic types are only known by the hash in their topic
+func (a ABI) DecodeLog(topics []types.Hash, data string) (*ABIDecoded, error) {
+	if len(topics) == 0 {
+		return nil, errors.New("anonymous event")
+	}
+	for _, entry := range a {
+		if entry.Type != "event" || entry.Anonymous || entry.Topic() != types.Hash(strings.ToLower(string(topics[0]))) {
+			continue
+		}
+		var indexed, unindexed []ABIArgument
+		for _, arg := range entry.Inputs {
+			if arg.Indexed {
+				indexed = append(indexed, arg)
+			} else {
+				unindexed = append(unindexed, arg)
+			}
+		}
+		if len(indexed) != len(topics)-1 {
+			continue
+		}
+		raw, err := hexToBytes(data)
+		if err != nil {
+			return nil, err
+		}
+		decoded, err := entry.decode(unindexed, raw)
+		if err != nil {
+			return nil, err
+		}
+		args, topic := make([]*ABIValue, 0, len(entry.Inputs)), 1
+		for _, arg := range entry.Inputs {
+			if !arg.Indexed {
+				args, decoded.Args = append(args, decoded.Args[0]), decoded.Args[1:]
+				continue
+			}
+			value := &ABIValue{Name: arg.Name, Type: arg.canonical(), Value: string(topics[topic])}
+			if t, err := newABIType(arg.Type, arg.Components); err == nil && !t.dynamic() && t.kind != "array" && t.kind != "tuple" {
+				word, _ := hexToBytes(string(topics[topic]))
+				if value.Value, err = t.decode(word); err != nil {
+					return nil, err
+				}
+			}
+			args, topic = append(args, value), topic+1
+		}
+		decoded.Args = args
+		return decoded, nil
+	}
+	return nil, fmt.Errorf("unknown event %s", topics[0])
+}
+
+func (e *ABIEntry) decode(args []ABIArgument, data []byte) (*ABIDecoded, error) {
+	values, err := DecodeABIArgs(args, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", e.Signature(), err)
+	}
+	return &ABIDecoded{Name: e.Name, Signature: e.Signature(), Args: values}, nil
+}
+
+// DecodeABIArgs decodes the ABI encoded arguments, e.g. the call data without the selector or the return data
+func DecodeABIArgs(args []ABIArgument, data []byte) ([]*ABIValue, error) {
+	t, err := newABITuple(args)
+	if err != nil {
+		return nil, err
+	}
+	value, err := t.decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return value.([]*ABIValue), nil
+}
+
+// EncodeABIArgs encodes the arguments, the values are taken as decoded from JSON: numbers as JSON numbers or
+// decimal or hex strings, addresses and bytes as hex strings, arrays as lists, tuples as lists or objects by field name
+func EncodeABIArgs(args []ABIArgument, values []any) ([]byte, error) {
+	t, err := newABITuple(args)
+	if err != nil {
+		return nil, err
+	}
+	return t.encode(values)
+}
+
+func (a *ABIArgument) canonical() string {
+	if strings.HasPrefix(a.Type, "tuple") {
+		params := make([]string, len(a.Components))
+		for i, component := range a.Components {
+			params[i] = component.canonical()
+		}
+		return "(" + strings.Join(params, ",") + ")" + a.Type[len("tuple"):]
+	}
+	// uint and int are aliases of uint256 and int256, also as array elements
+	base, suffix, _ := strings.Cut(a.Type, "[")
+	if base == "uint" || base == "int" {
+		base += "256"
+	}
+	if suffix != "" || strings.HasSuffix(a.Type, "[") {
+		return base + "[" + suffix
+	}
+	return base
+}
+
+// abiType parsed solidity type
+type abiType struct {
+	kind   string     // uint, int, address, bool, fixedbytes, bytes, string, slice, array, tuple
+	size   int        // bits of uint and int, bytes of fixedbytes, length of array
+	elem   *abiType   // element of slice and array
+	fields []*abiType // fields of tuple
+	names  []string   // field names of tuple
+	types  []string   // field types of tuple
+}
+
+func newABITuple(args []ABIArgument) (*abiType, error) {
+	t := &abiType{kind: "tuple"}
+	for _, arg := range args {
+		field, err := newABIType(arg.Type, arg.Components)
+		if err != nil {
+			return nil, err
+		}
+		t.fields, t.names, t.types = append(t.fields, field), append(t.names, arg.Name), append(t.types, arg.canonical())
+	}
+	return t, nil
+}
+
+func newABIType(name string, components []ABIArgument) (*abiType, error) {
+	if strings.HasSuffix(name, "]") {
+		i := strings.LastIndex(name, "[")
+		if i < 0 {
+			return nil, fmt.Errorf("invalid type %s", name)
+		}
+		elem, err := newABIType(name[:i], components)
+		if err != nil {
+			return nil, err
+		}
+		if length := name[i+1 : len(name)-1]; length == "" {
+			return &abiType{kind: "slice", elem: elem}, nil
+		} else if size, err := strconv.Atoi(length); err == nil && size > 0 {
+			return &abiType{kind: "array", size: size, elem: elem}, nil
+		}
+		return nil, fmt.Errorf("invalid array length %s", name)
+	}
+	switch {
+	case name == "tuple":
+		return newABITuple(components)
+	case name == "address" || name == "bool" || name == "string" || name == "bytes":
+		return &abiType{kind: name}, nil
+	case name == "function":
+		return &abiType{kind: "fixedbytes", size: 24}, nil
+	case strings.HasPrefix(name, "uint") || strings.HasPrefix(name, "int"):
+		kind := "uint"
+		if name[0] == 'i' {
+			kind = "int"
+		}
+		size := 256
+		if bits := name[len(kind):]; bits != "" {
+			var err error
+			if size, err = strconv.Atoi(bits); err != nil || size < 8 || size > 256 || size%8 != 0 {
+				return nil, fmt.Errorf("invalid type %s", name)
+			}
+		}
+		return &abiType{kind: kind, size: size}, nil
+	case strings.HasPrefix(name, "bytes"):
+		if size, err := strconv.Atoi(name[len("bytes"):]); err == nil && size > 0 && size <= 32 {
+			return &abiType{kind: "fixedbytes", size: size}, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported type %s", name)
+}
+
+// dynamic reports whether the value is encoded in the tail, with its offset in the head
+func (t *abiType) dynamic() bool {
+	switch t.kind {
+	case "string", "bytes", "slice":
+		return true
+	case "array":
+		return t.elem.dynamic()
+	case "tuple":
+		for _, field := range t.fields {
+			if field.dynamic() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// headSize returns the size of the value in the head of the enclosing tuple
+func (t *abiType) headSize() int {
+	if t.dynamic() {
+		return 32
+	}
+	switch t.kind {
+	case "array":
+		return t.size * t.elem.headSize()
+	case "tuple":
+		size := 0
+		for _, field := range t.fields {
+			size += field.headSize()
+		}
+		return size
+	}
+	return 32
+}
+
+// components returns the types of the values encoded as a tuple: the fields of a tuple or the elements of an array
+func (t *abiType) components(length int) []*abiType {
+	if t.kind == "tuple" {
+		return t.fields
+	}
+	elems := make([]*abiType, length)
+	for i := range elems {
+		elems[i] = t.elem
+	}
+	return elems
+}
+
+var (
+	tt256   = new(big.Int).Lsh(big.NewInt(1), 256)
+	tt255   = new(big.Int).Lsh(big.NewInt(1), 255)
+	tt256m1 = new(big.Int).Sub(tt256, big.NewInt(1))
+)
+
+func readWord(data []byte, pos int) ([]byte, error) {
+	if pos < 0 || pos+32 > len(data) {
+		return nil, errors.New("data too short")
+	}
+	return data[pos : pos+32], nil
+}
+
+func readLength(data []byte, pos int) (int, error) {
+	word, err := readWord(data, pos)
+	if err != nil {
+		return 0, err
+	}
+	length := new(big.Int).SetBytes(word)
+	if !length.IsInt64() || length.Int64() > int64(len(data)) {
+		return 0, errors.New("length or offset out of range")
+	}
+	return int(length.Int64()), nil
+}
+
+// decode decodes the value at the start of the data, the offsets of the dynamic values are relative to it
+func (t *abiType) decode(data []byte) (any, error) {
+	switch t.kind {
+	case "slice", "array", "tuple":
+		length := t.size
+		if t.kind == "slice" {
+			var err error
+			if length, err = readLength(data, 0); err != nil {
+				return nil, err
+			}
+			data = data[32:]
+			// every element takes at least one word
+			if length*32 > len(data) {
+				return nil, errors.New("array length out of range")
+			}
+		}
+		components := t.components(length)
+		values, pos := make([]any, len(components)), 0
+		for i, component := range components {
+			var err error
+			if component.dynamic() {
+				var offset int
+				if offset, err = readLength(data, pos); err == nil {
+					values[i], err = component.decode(data[offset:])
+				}
+			} else {
+				values[i], err = component.decode(data[pos:])
+			}
+			if err != nil {
+				return nil, err
+			}
+			pos += component.headSize()
+		}
+		if t.kind != "tuple" {
+			return values, nil
+		}
+		args := make([]*ABIValue, len(values))
+		for i, value := range values {
+			args[i] = &ABIValue{Name: t.names[i], Type: t.types[i], Value: value}
+		}
+		return args, nil
+	case "string", "bytes":
+		length, err := readLength(data, 0)
+		if err != nil {
+			return nil, err
+		}
+		if 32+length > len(data) {
+			return nil, errors.New("data too short")
+		}
+		if t.kind == "string" {
+			return string(data[32 : 32+length]), nil
+		}
+		return "0x" + hex.EncodeToString(data[32:32+length]), nil
+	}
+	word, err := readWord(data, 0)
+	if err != nil {
+		return nil, err
+	}
+	switch t.kind {
+	case "uint":
+		return new(big.Int).SetBytes(word).String(), nil
+	case "int":
+		value := new(big.Int).SetBytes(word)
+		if value.Cmp(tt255) >= 0 {
+			value.Sub(value, tt256)
+		}
+		return value.String(), nil
+	case "address":
+		return "0x" + hex.EncodeToString(word[12:]), nil
+	case "bool":
+		return word[31] == 1, nil
+	default:
+		return "0x" + hex.EncodeToString(word[:t.size]), nil
+	}
+}
+
+// encode encodes the value, the offsets of the dynamic values are relative to its start
+func (t *abiType) encode(value any) ([]byte, error) {
+	switch t.kind {
+	case "slice", "array", "tuple":
+		values, err := t.list(value)
+		if err != nil {
+			return nil, err
+		}
+		if t.kind != "slice" && len(values) != len(t.components(t.size)) {
+			return nil, fmt.Errorf("expected %d values, got %d", len(t.components(t.size)), len(values))
+		}
+		components := t.components(len(values))
+		headSize := 0
+		for _, component := range components {
+			headSize += component.headSize()
+		}
+		var head, tail []byte
+		for i, component := range components {
+			encoded, err := component.encode(values[i])
+			if err != nil {
+				return nil, err
+			}
+			if component.dynamic() {
+				head = append(head, word(big.NewInt(int64(headSize+len(tail))))...)
+				tail = append(tail, encoded...)
+			} else {
+				head = append(head, encoded...)
+			}
+		}
+		if t.kind == "slice" {
+			head = append(word(big.NewInt(int64(len(values)))), head...)
+		}
+		return append(head, tail...), nil
+	case "string", "bytes":
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected a string for %s", t.kind)
+		}
+		data := []byte(text)
+		if t.kind == "bytes" {
+			var err error
+			if data, err = hexToBytes(text); err != nil {
+				return nil, fmt.Errorf("invalid hex bytes %s", text)
+			}
+		}
+		padded := make([]byte, (len(data)+31)/32*32)
+		copy(padded, data)
+		return append(word(big.NewInt(int64(len(data)))), padded...), nil
+	case "uint", "int":
+		number, err := toBigInt(value)
+		if err != nil {
+			return nil, err
+		}
+		limit := new(big.Int).Lsh(big.NewInt(1), uint(t.size))
+		if t.kind == "int" {
+			limit.Rsh(limit, 1)
+			if number.Cmp(limit) >= 0 || number.Cmp(new(big.Int).Neg(limit)) < 0 {
+				return nil, fmt.Errorf("%s out of range for int%d", number, t.size)
+			}
+			return word(new(big.Int).And(number, tt256m1)), nil
+		}
+		if number.Sign() < 0 || number.Cmp(limit) >= 0 {
+			return nil, fmt.Errorf("%s out of range for uint%d", number, t.size)
+		}
+		return word(number), nil
+	case "address":
+		text, _ := value.(string)
+		data, err := hexToBytes(text)
+		if err != nil || len(data) != 20 {
+			return nil, fmt.Errorf("invalid address %v", value)
+		}
+		return word(new(big.Int).SetBytes(data)), nil
+	case "bool":
+		flag, ok := value.(bool)
+		if !ok {
+			text, _ := value.(string)
+			if flag, ok = text == "true", text == "true" || text == "false"; !ok {
+				return nil, fmt.Errorf("invalid bool %v", value)
+			}
+		}
+		if flag {
+			return word(big.NewInt(1)), nil
+		}
+		return word(new(big.Int)), nil
+	default:
+		text, _ := value.(string)
+		data, err := hexToBytes(text)
+		if err != nil || len(data) != t.size {
+			return nil, fmt.Errorf("invalid bytes%d %v", t.size, value)
+		}
+		padded := make([]byte, 32)
+		copy(padded, data)
+		return padded, nil
+	}
+}
+
+// list returns the values of an array, or of a tuple given as a list or an object by field name
+func (t *abiType) list(value any) ([]any, error) {
+	switch value := value.(type) {
+	case []any:
+		return value, nil
+	case map[string]any:
+		if t.kind == "tuple" {
+			values := make([]any, len(t.names))
+			for i, name := range t.names {
+				var ok bool
+				if values[i], ok = value[name]; !ok {
+					return nil, fmt.Errorf("missing tuple field %s", name)
+				}
+			}
+			return values, nil
+		}
+	}
+	return nil, fmt.Errorf("expected a list for %s", t.kind)
+}
+
+func word(number *big.Int) []byte {
+	data := make([]byte, 32)
+	return number.FillBytes(data)
+}
+
+func toBigInt(value any) (*big.Int, error) {
+	switch value := value.(type) {
+	case string:
+		if number, ok := new(big.Int).SetString(value, 0); ok {
+			return number, nil
+		}
+	case json.Number:
+		if number, ok := new(big.Int).SetString(value.String(), 10); ok {
+			return number, nil
+		}
+	case float64:
+		if number, accuracy := big.NewFloat(value).Int(nil); accuracy == big.Exact {
+			return number, nil
+		}
+	case int:
+		return big.NewInt(int64(value)), nil
+	case int64:
+		return big.NewInt(value), nil
+	}
+	return nil, fmt.Errorf("invalid number %v", value)
+}
+
+func hexToBytes(text string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(text, "0x"), "0X"))
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"fmt"
 	"math/big"
 	"strconv"
 
@@ -23,6 +24,10 @@ var (
 	approveSelector           = "0x095ea7b3"
 )
 
+// errReturnData is the error of the return data that is not the one of the called function, e.g. the contract does
+// not implement it
+var errReturnData = errors.New("return data format error")
+
 type ContractClient interface {
 	CallContract(ctx context.Context, to, data, number any) (types.Bytes, error)
 }
@@ -122,31 +127,41 @@ func Approve(c ContractClient, ctx context.Context, number, address any, spender
 func ABIDecodeString(out types.Bytes) (string, error) {
 	outLen := len(out)
 	if outLen < 130 || (outLen-2)%64 != 0 || out[64:66] != "20" {
-		return "", errors.New("return data format error")
+		return "", errReturnData
+	}
+	// the data may come from any contract, e.g. a revert reason, the length is checked before it is used
+	strLen, ok := new(big.Int).SetString(string(out[66:130]), 16)
+	if !ok || !strLen.IsUint64() || strLen.Uint64() > uint64(outLen-130)/2 {
+		return "", fmt.Errorf("%w: string length", errReturnData)
+	}
+	n := int(strLen.Uint64())
+	if (outLen-130)/64 != (n+31)/32 {
+		return "", fmt.Errorf("%w: string length", errReturnData)
 	}
-	strLen := new(big.Int)
-	strLen.SetString(string(out[66:130]), 16)
-	if (outLen-130)/64 != int(strLen.Int64())/32 {
-		return "", errors.New("return data string length error")
+	data, err := hex.DecodeString(string(out[130 : 130+n*2]))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errReturnData, err)
 	}
-	data, err := hex.DecodeString(string(out[130:int(130+strLen.Int64()*2)]))
-	return string(data), err
+	return string(data), nil
 }
 
 // ABIDecodeUint8 parses uint8 from the return data with only one contract return value
 func ABIDecodeUint8(out types.Bytes) (uint8, error) {
 	outLen := len(out)
 	if outLen != 66 || out[:50] != "0x000000000000000000000000000000000000000000000000" {
-		return 0, errors.New("return data format error")
+		return 0, errReturnData
 	}
 	data, err := strconv.ParseUint(string(out[50:]), 16, 8)
-	return uint8(data), err
+	if err != nil {
+		return 0, fmt.Errorf("%w: %v", errReturnData, err)
+	}
+	return uint8(data), nil
 }
 
 // ABIDecodeBigInt parses uint256 from the return data with only one contract return value
 func ABIDecodeBigInt(out types.Bytes) (string, error) {
 	if len(out) != 66 {
-		return "", errors.New("return data format error")
+		return "", errReturnData
 	}
 	return string(out), nil
 }
@@ -155,7 +170,7 @@ func ABIDecodeBigInt(out types.Bytes) (string, error) {
 func ABIDecodeBool(out types.Bytes) (bool, error) {
 	outLen := len(out)
 	if outLen != 66 || out[:65] != "0x00000000000000000000000000000000000000000000000000000000000000" {
-		return false, errors.New("return data format error")
+		return false, errReturnData
 	}
 	return out[65] == '1', nil
 }
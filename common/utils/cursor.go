@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// EncodeCursor encodes the key values of the last row of a page into the opaque cursor of the next page
+func EncodeCursor(keys ...any) string {
+	data, _ := json.Marshal(keys)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor decodes the key values of the cursor, the integers as int64 and the other values as strings
+func DecodeCursor(cursor string, count int) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.New("invalid cursor")
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var keys []any
+	if err = decoder.Decode(&keys); err != nil || len(keys) != count {
+		return nil, errors.New("invalid cursor")
+	}
+	for i, key := range keys {
+		switch key := key.(type) {
+		case json.Number:
+			if keys[i], err = key.Int64(); err != nil {
+				return nil, errors.New("invalid cursor")
+			}
+		case string:
+		default:
+			return nil, errors.New("invalid cursor")
+		}
+	}
+	return keys, nil
+}
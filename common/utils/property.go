@@ -2,10 +2,12 @@ package utils
 
 import (
 	"context"
+	"errors"
 	"strings"
 
 	"server/common/model"
 	"server/common/types"
+	"server/node"
 )
 
 var (
@@ -67,6 +69,71 @@ func SetProperty(c ContractClient, ctx context.Context, number string, account *
 	return
 }
 
+// SetTokenProperty sets the decimals of the ERC20 token and the total supply at the block number,
+// the optional functions the contract does not implement are left empty
+func SetTokenProperty(c ContractClient, ctx context.Context, number string, token *model.Token) (err error) {
+	if token.Type != nil && *token.Type == types.ERC20 {
+		decimals, err := Decimals(c, ctx, number, token.Address)
+		if err == nil {
+			token.Decimals = new(types.Long)
+			*token.Decimals = types.Long(decimals)
+		}
+		if err = filterContractErr(err); err != nil {
+			return err
+		}
+	}
+	return SetTokenSupply(c, ctx, number, token)
+}
+
+// SetTokenSupply sets the total supply of the token at the block number, it is left empty when the contract
+// does not implement totalSupply
+func SetTokenSupply(c ContractClient, ctx context.Context, number string, token *model.Token) error {
+	out, err := c.CallContract(ctx, token.Address, totalSupplySelector, number)
+	return setTokenSupply(token, out, err)
+}
+
+// BatchContractClient sends the contract calls in batches
+type BatchContractClient interface {
+	BatchCallContext(ctx context.Context, b []node.BatchElem) error
+}
+
+// SetTokenSupplies sets the total supply of the tokens at the block number like SetTokenSupply, the calls are sent
+// in batches
+func SetTokenSupplies(c BatchContractClient, ctx context.Context, number string, tokens []*model.Token) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	batch, supplies := make([]node.BatchElem, len(tokens)), make([]types.Bytes, len(tokens))
+	for i, token := range tokens {
+		batch[i] = node.BatchElem{
+			Method: "eth_call",
+			Args:   []any{map[string]any{"to": token.Address, "data": totalSupplySelector}, number},
+			Result: &supplies[i],
+		}
+	}
+	if err := c.BatchCallContext(ctx, batch); err != nil {
+		return err
+	}
+	for i, token := range tokens {
+		if err := setTokenSupply(token, supplies[i], batch[i].Error); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setTokenSupply(token *model.Token, out types.Bytes, err error) error {
+	var supply string
+	if err == nil {
+		supply, err = ABIDecodeBigInt(out)
+	}
+	if err == nil {
+		token.TotalSupply = new(types.BigInt)
+		*token.TotalSupply = HexToBigInt(supply[2:])
+	}
+	return filterContractErr(err)
+}
+
 func IsERC165(c ContractClient, ctx context.Context, number, address any) (bool, error) {
 	support, err := SupportsInterface(c, ctx, number, address, erc165InterfaceId)
 	if !support || err != nil {
@@ -114,15 +181,29 @@ func IsERC20(c ContractClient, ctx context.Context, number, address any) (bool,
 	return true, nil
 }
 
-// filterContractErr Filter out errors except network connections
+// executionErrors the messages of the failed calls that the contract code raised
+var executionErrors = []string{
+	"execution reverted",
+	"invalid opcode",
+	"invalid jump destination",
+	"out of gas",
+	"stack underflow",
+	"stack overflow",
+	"write protection",
+	"return data out of bounds",
+}
+
+// filterContractErr drops the errors of the contracts that do not implement the call: the call failed in the
+// contract code or returned other data. The other errors, e.g. the node is unreachable or timed out, are returned
 func filterContractErr(err error) error {
-	if err != nil {
-		if strings.Index(err.Error(), "connection") > 0 {
-			return err
-		}
-		if strings.Index(err.Error(), "unexpected EOF") > 0 {
-			return err
+	if err == nil || errors.Is(err, errReturnData) {
+		return nil
+	}
+	message := strings.ToLower(err.Error())
+	for _, execution := range executionErrors {
+		if strings.Contains(message, execution) {
+			return nil
 		}
 	}
-	return nil
+	return err
 }
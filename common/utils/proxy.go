@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"context"
+	"strings"
+
+	"server/common/model"
+	"server/common/types"
+)
+
+var (
+	eip1967ImplementationSlot = "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc" // keccak256("eip1967.proxy.implementation") - 1
+	eip1967BeaconSlot         = "0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50" // keccak256("eip1967.proxy.beacon") - 1
+	eip1822ProxiableSlot      = "0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7" // keccak256("PROXIABLE")
+	implementationSelector    = "0x5c60da1b"                                                         // implementation()
+
+	// the runtime code of an EIP-1167 minimal proxy is the prefix, PUSH20 of the implementation and the suffix
+	eip1167Prefix = "0x363d3d373d3d3d363d73"
+	eip1167Suffix = "5af43d82803e903d91602b57fd5bf3"
+
+	UpgradedTopic       = types.Hash("0xbc7cd75a20ee27fd9adebab32041f755214dbc6bffa90cc0225b39da2e5c2d3b") // Upgraded(address)
+	BeaconUpgradedTopic = types.Hash("0x1cf3b03a6cf19fa2baba4df148e9dcabedea7f8a5c07840e207e5c089be95d3e") // BeaconUpgraded(address)
+)
+
+type StorageClient interface {
+	ContractClient
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+}
+
+// DetectProxy recognizes the EIP-1167 minimal proxy by its code and reads the EIP-1967 implementation and beacon slots
+// and the EIP-1822 slot of the contract at the block number, returns nil when the contract is not a proxy
+func DetectProxy(c StorageClient, ctx context.Context, number string, address types.Address, code string) (proxy *model.ProxyImplementation, err error) {
+	code = strings.ToLower(code)
+	if len(code) == len(eip1167Prefix)+40+len(eip1167Suffix) && strings.HasPrefix(code, eip1167Prefix) && strings.HasSuffix(code, eip1167Suffix) {
+		implementation := types.Address("0x" + code[len(eip1167Prefix):len(eip1167Prefix)+40])
+		return &model.ProxyImplementation{Address: address, Kind: "eip1167", Implementation: implementation}, nil
+	}
+	implementation, err := storageAddress(c, ctx, number, address, eip1967ImplementationSlot)
+	if err != nil || implementation != nil {
+		return newProxy(address, "eip1967", implementation), err
+	}
+	beacon, err := storageAddress(c, ctx, number, address, eip1967BeaconSlot)
+	if err != nil {
+		return
+	}
+	if beacon != nil {
+		if implementation, err = BeaconImplementation(c, ctx, number, *beacon); err != nil || implementation == nil {
+			return
+		}
+		proxy = newProxy(address, "eip1967-beacon", implementation)
+		proxy.Beacon = beacon
+		return
+	}
+	implementation, err = storageAddress(c, ctx, number, address, eip1822ProxiableSlot)
+	return newProxy(address, "eip1822", implementation), err
+}
+
+// BeaconImplementation calls implementation() of the upgradeable beacon, returns nil when the contract has no such function
+func BeaconImplementation(c ContractClient, ctx context.Context, number string, beacon types.Address) (*types.Address, error) {
+	out, err := c.CallContract(ctx, beacon, implementationSelector, number)
+	if err != nil {
+		return nil, filterContractErr(err)
+	}
+	return wordAddress(string(out)), nil
+}
+
+func newProxy(address types.Address, kind string, implementation *types.Address) *model.ProxyImplementation {
+	if implementation == nil {
+		return nil
+	}
+	return &model.ProxyImplementation{Address: address, Kind: kind, Implementation: *implementation}
+}
+
+// storageAddress reads the address stored in the slot, nil when the slot is empty
+func storageAddress(c StorageClient, ctx context.Context, number string, address types.Address, slot string) (*types.Address, error) {
+	var word string
+	if err := c.CallContext(ctx, &word, "eth_getStorageAt", address, slot, number); err != nil {
+		return nil, err
+	}
+	return wordAddress(word), nil
+}
+
+// wordAddress returns the address in the low 20 bytes of the 32 bytes word, nil when the word is not an address or zero
+func wordAddress(word string) *types.Address {
+	word = strings.ToLower(strings.TrimPrefix(word, "0x"))
+	if len(word) != 64 || strings.Trim(word[:24], "0") != "" {
+		return nil
+	}
+	address := types.Address("0x" + word[24:])
+	if address == types.ZeroAddress {
+		return nil
+	}
+	return &address
+}
@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	"server/common/types"
+)
+
+var (
+	errorSelector = "0x08c379a0" // Error(string)
+	panicSelector = "0x4e487b71" // Panic(uint256)
+)
+
+// panicReasons are the descriptions of the solidity panic codes
+var panicReasons = map[int64]string{
+	0x00: "generic compiler panic",
+	0x01: "assertion failed",
+	0x11: "arithmetic underflow or overflow",
+	0x12: "division or modulo by zero",
+	0x21: "invalid enum value",
+	0x22: "invalid storage byte array encoding",
+	0x31: "pop on empty array",
+	0x32: "array index out of bounds",
+	0x41: "out of memory",
+	0x51: "call to uninitialized function",
+}
+
+// customErrors are the signatures of the custom errors known from the contract ABIs, by selector
+var customErrors sync.Map
+
+// RegisterError makes the custom error known to DecodeRevert, the signature is like "InsufficientBalance(uint256,uint256)"
+func RegisterError(signature string) {
+	selector := "0x" + string(Keccak256Hash([]byte(signature)))[:8]
+	customErrors.Store(selector, signature)
+}
+
+// DecodeRevert decodes the revert data of a failed call: the reason of Error(string), the code and description of
+// Panic(uint256), or the signature of a registered custom error followed by its raw arguments
+func DecodeRevert(out types.Bytes) (reason *string, panicCode *types.Long) {
+	data := strings.ToLower(string(out))
+	if len(data) < 10 || !strings.HasPrefix(data, "0x") {
+		return
+	}
+	selector, args := data[:10], types.Bytes("0x"+data[10:])
+	switch selector {
+	case errorSelector:
+		if text, err := ABIDecodeString(args); err == nil {
+			reason = &text
+		}
+	case panicSelector:
+		if code, ok := new(big.Int).SetString(string(args[2:]), 16); ok && len(args) == 66 && code.IsInt64() {
+			text, known := panicReasons[code.Int64()]
+			if !known {
+				text = "unknown panic"
+			}
+			value := types.Long(code.Int64())
+			panicCode, reason = &value, &text
+		}
+	default:
+		if signature, ok := customErrors.Load(selector); ok {
+			text := signature.(string)
+			if len(args) > 2 {
+				text = fmt.Sprintf("%s %s", text, args)
+			}
+			reason = &text
+		}
+	}
+	return
+}
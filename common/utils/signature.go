@@ -0,0 +1,153 @@
+package utils
+
+import (
+	_ "embed"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+
+	"server/common/types"
+)
+
+//go:embed signatures.txt
+var builtinSignatures string
+
+// signatureRegexp matches a canonical text signature, the parameter types are checked by the ABI type parser
+var signatureRegexp = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*\(.*\)$`)
+
+// signatures are the known text signatures by 4 bytes function selector or 32 bytes event topic
+var signatures = struct {
+	sync.RWMutex
+	m map[string][]string
+}{m: map[string][]string{}}
+
+func init() {
+	for _, line := range strings.Split(builtinSignatures, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		kind, text, _ := strings.Cut(line, " ")
+		if _, _, err := RegisterSignature(kind, text); err != nil {
+			panic("signatures.txt: " + line + ": " + err.Error())
+		}
+	}
+}
+
+// ParseSignature checks the text signature and returns it in the canonical form, without spaces and parameter names
+// are not allowed, uint and int are expanded to uint256 and int256
+func ParseSignature(text string) (string, error) {
+	entry, err := SignatureEntry("function", text)
+	if err != nil {
+		return "", err
+	}
+	return entry.Signature(), nil
+}
+
+// SignatureEntry returns the ABI entry of the text signature, its arguments have no names and a function has no outputs
+func SignatureEntry(kind, text string) (*ABIEntry, error) {
+	text = strings.Join(strings.Fields(text), "")
+	if !signatureRegexp.MatchString(text) {
+		return nil, errors.New("invalid signature " + text)
+	}
+	open := strings.IndexByte(text, '(')
+	args, err := parseSignatureTypes(text[open+1 : len(text)-1])
+	if err != nil {
+		return nil, errors.New("invalid signature " + text + ": " + err.Error())
+	}
+	return &ABIEntry{Type: kind, Name: text[:open], Inputs: args}, nil
+}
+
+// parseSignatureTypes splits the comma separated parameter types, tuples are written in parentheses
+func parseSignatureTypes(text string) (args []ABIArgument, err error) {
+	if text == "" {
+		return
+	}
+	depth, start := 0, 0
+	for i := 0; i <= len(text); i++ {
+		if i < len(text) && text[i] == '(' {
+			depth++
+		} else if i < len(text) && text[i] == ')' {
+			if depth--; depth < 0 {
+				return nil, errors.New("unbalanced parentheses")
+			}
+		} else if i == len(text) && depth != 0 {
+			return nil, errors.New("unbalanced parentheses")
+		} else if i == len(text) || (text[i] == ',' && depth == 0) {
+			arg := ABIArgument{Type: text[start:i]}
+			if strings.HasPrefix(arg.Type, "(") {
+				end := strings.LastIndexByte(arg.Type, ')')
+				if arg.Components, err = parseSignatureTypes(arg.Type[1:end]); err != nil {
+					return
+				}
+				arg.Type = "tuple" + arg.Type[end+1:]
+			}
+			if _, err = newABIType(arg.Type, arg.Components); err != nil {
+				return
+			}
+			args, start = append(args, arg), i+1
+		}
+	}
+	return
+}
+
+// SignatureHash returns the canonical text signature of a function or an event and its selector (function) or
+// topic (event)
+func SignatureHash(kind, text string) (signature, hash string, err error) {
+	if signature, err = ParseSignature(text); err != nil {
+		return
+	}
+	hash = "0x" + string(Keccak256Hash([]byte(signature)))
+	switch kind {
+	case "function":
+		hash = hash[:10]
+	case "event":
+	default:
+		return "", "", errors.New("invalid signature kind " + kind + ", function or event")
+	}
+	return
+}
+
+// RegisterSignature adds the text signature of a function or an event to the signature database, returns the
+// canonical signature and its selector (function) or topic (event)
+func RegisterSignature(kind, text string) (signature, hash string, err error) {
+	if signature, hash, err = SignatureHash(kind, text); err != nil {
+		return
+	}
+	signatures.Lock()
+	defer signatures.Unlock()
+	for _, known := range signatures.m[hash] {
+		if known == signature {
+			return
+		}
+	}
+	signatures.m[hash] = append(signatures.m[hash], signature)
+	return
+}
+
+// LookupSignatures returns a copy of the text signatures of the selector or topic, more than one when it is ambiguous
+func LookupSignatures(hash string) []string {
+	signatures.RLock()
+	defer signatures.RUnlock()
+	if known := signatures.m[strings.ToLower(hash)]; len(known) > 0 {
+		return append([]string(nil), known...)
+	}
+	return nil
+}
+
+// MethodSignatures returns the text signatures of the function called by the transaction input
+func MethodSignatures(input string) []string {
+	if len(input) < 10 || !strings.HasPrefix(input, "0x") {
+		return nil
+	}
+	return LookupSignatures(input[:10])
+}
+
+// EventSignatures returns the text signatures of the event of the log topics
+func EventSignatures(topics []types.Hash) []string {
+	if len(topics) == 0 {
+		return nil
+	}
+	return LookupSignatures(string(topics[0]))
+}
@@ -18,8 +18,8 @@ import (
 var (
 	erc20TransferEventId         = Hash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
 	erc721TransferEventId        = Hash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
-	erc1155TransferSingleEventId = Hash("0x7b912cc6629daab379d004780e875cdb7625e8331d3a7c8fbe08a42156325546")
-	erc1155TransferBatchEventId  = Hash("0x20114eb39ee5dfdb13684c7d9e951052ef22c89bff67131a9bf08879189b0f71")
+	erc1155TransferSingleEventId = Hash("0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62")
+	erc1155TransferBatchEventId  = Hash("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb")
 	loc, _                       = time.LoadLocation("Local")
 	DaySecond                    = 24 * time.Hour.Milliseconds() / 1000
 )
@@ -30,34 +30,40 @@ func UnpackTransferLog(log *EventLog) []any {
 		if log.Topics[0] == erc20TransferEventId && len(log.Data) == 66 {
 			// Parse ERC20 transition events
 			return []any{&ERC20Transfer{
-				TxHash:  log.TxHash,
-				Address: log.Address,
-				From:    Address("0x" + log.Topics[1][26:]),
-				To:      Address("0x" + log.Topics[2][26:]),
-				Value:   HexToBigInt(log.Data[2:66]),
+				TxHash:      log.TxHash,
+				BlockNumber: log.BlockNumber,
+				LogIndex:    log.Index,
+				Address:     log.Address,
+				From:        Address("0x" + log.Topics[1][26:]),
+				To:          Address("0x" + log.Topics[2][26:]),
+				Value:       HexToBigInt(log.Data[2:66]),
 			}}
 		}
 	} else if topicsLen == 4 {
 		if log.Topics[0] == erc721TransferEventId && len(log.Data) == 2 {
 			// Parse ERC721 transition events
 			return []any{&ERC721Transfer{
-				TxHash:  log.TxHash,
-				Address: log.Address,
-				From:    Address("0x" + log.Topics[1][26:]),
-				To:      Address("0x" + log.Topics[2][26:]),
-				TokenId: HexToBigInt(string(log.Topics[3][2:])),
+				TxHash:      log.TxHash,
+				BlockNumber: log.BlockNumber,
+				LogIndex:    log.Index,
+				Address:     log.Address,
+				From:        Address("0x" + log.Topics[1][26:]),
+				To:          Address("0x" + log.Topics[2][26:]),
+				TokenId:     HexToBigInt(string(log.Topics[3][2:])),
 			}}
 		} else if log.Topics[0] == erc1155TransferSingleEventId && len(log.Data) == 130 {
 			// Parse ERC1155 transition events
 			operator, from, to := Address("0x"+log.Topics[1][26:]), Address("0x"+log.Topics[2][26:]), Address("0x"+log.Topics[3][26:])
 			return []any{&ERC1155Transfer{
-				TxHash:   log.TxHash,
-				Address:  log.Address,
-				Operator: operator,
-				From:     from,
-				To:       to,
-				TokenId:  HexToBigInt(log.Data[2:66]),
-				Value:    HexToBigInt(log.Data[66:130]),
+				TxHash:      log.TxHash,
+				BlockNumber: log.BlockNumber,
+				LogIndex:    log.Index,
+				Address:     log.Address,
+				Operator:    operator,
+				From:        from,
+				To:          to,
+				TokenId:     HexToBigInt(log.Data[2:66]),
+				Value:       HexToBigInt(log.Data[66:130]),
 			}}
 		} else if log.Topics[0] == erc1155TransferBatchEventId {
 			// Parse the batch transfer events of ERC1155
@@ -73,13 +79,16 @@ func UnpackTransferLog(log *EventLog) []any {
 			for i := 0; i < transferCount; i++ {
 				idOffset, valueOffset := 2+(i+3)*64, 2+(transferCount+i+4)*64
 				transferLogs[i] = &ERC1155Transfer{
-					TxHash:   log.TxHash,
-					Address:  log.Address,
-					Operator: operator,
-					From:     from,
-					To:       to,
-					TokenId:  HexToBigInt(log.Data[idOffset : idOffset+64]),
-					Value:    HexToBigInt(log.Data[valueOffset : valueOffset+64]),
+					TxHash:      log.TxHash,
+					BlockNumber: log.BlockNumber,
+					LogIndex:    log.Index,
+					BatchIndex:  Long(i),
+					Address:     log.Address,
+					Operator:    operator,
+					From:        from,
+					To:          to,
+					TokenId:     HexToBigInt(log.Data[idOffset : idOffset+64]),
+					Value:       HexToBigInt(log.Data[valueOffset : valueOffset+64]),
 				}
 			}
 			return transferLogs
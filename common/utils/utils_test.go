@@ -1,12 +1,15 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"math/big"
 	"testing"
 
 	"server/common/model"
 	"server/common/types"
+	"server/node"
 )
 
 func TestBigToAddress(t *testing.T) {
@@ -68,4 +71,186 @@ func TestUnpack1155TransferLog(t *testing.T) {
 	}
 	transferLog := UnpackTransferLog(&log)
 	t.Logf("%+v%+v", transferLog[0], transferLog[1])
+	for i, transfer := range transferLog {
+		if index := transfer.(*model.ERC1155Transfer).BatchIndex; index != types.Long(i) {
+			t.Fatalf("transfer %v has batch index %v", i, index)
+		}
+	}
+}
+
+func TestDecodeRevert(t *testing.T) {
+	reason, _ := DecodeRevert("0x08c379a0000000000000000000000000000000000000000000000000000000000000002000000000000000000000000000000000000000000000000000000000000000184552433732313a20696e76616c696420746f6b656e2049440000000000000000")
+	if reason == nil || *reason != "ERC721: invalid token ID" {
+		t.Fatal("Error(string) not decoded")
+	}
+	reason, code := DecodeRevert("0x4e487b710000000000000000000000000000000000000000000000000000000000000011")
+	if code == nil || *code != 0x11 {
+		t.Fatal("Panic(uint256) not decoded")
+	}
+	t.Log(*reason)
+	// the length words beyond the data must not be sliced
+	offset, data := "0000000000000000000000000000000000000000000000000000000000000020", "4552433732313a20696e76616c696420746f6b656e2049440000000000000000"
+	for _, length := range []string{
+		"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+		"8000000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000021",
+		"00000000000000000000000000000000000000000000000000000000000000ff",
+	} {
+		for _, revert := range []string{"0x08c379a0" + offset + length, "0x08c379a0" + offset + length + data} {
+			if reason, _ := DecodeRevert(types.Bytes(revert)); reason != nil {
+				t.Fatalf("length %v decoded as %q", length, *reason)
+			}
+		}
+	}
+}
+
+func TestABICodec(t *testing.T) {
+	// the examples of https://docs.soliditylang.org/en/latest/abi-spec.html#examples
+	abi, err := ParseABI([]byte(`[
+		{"type":"function","name":"f","inputs":[{"name":"a","type":"uint"},{"name":"b","type":"uint32[]"},{"name":"c","type":"bytes10"},{"name":"d","type":"bytes"}]},
+		{"type":"function","name":"g","inputs":[{"name":"a","type":"uint256[][]"},{"name":"b","type":"string[]"}]}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f := "0x8be65246" +
+		"0000000000000000000000000000000000000000000000000000000000000123" +
+		"0000000000000000000000000000000000000000000000000000000000000080" +
+		"3132333435363738393000000000000000000000000000000000000000000000" +
+		"00000000000000000000000000000000000000000000000000000000000000e0" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000456" +
+		"0000000000000000000000000000000000000000000000000000000000000789" +
+		"000000000000000000000000000000000000000000000000000000000000000d" +
+		"48656c6c6f2c20776f726c642100000000000000000000000000000000000000"
+	g := "0x2289b18c" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"0000000000000000000000000000000000000000000000000000000000000140" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"00000000000000000000000000000000000000000000000000000000000000a0" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000000000000000000000000000000000000000000003" +
+		"0000000000000000000000000000000000000000000000000000000000000003" +
+		"0000000000000000000000000000000000000000000000000000000000000060" +
+		"00000000000000000000000000000000000000000000000000000000000000a0" +
+		"00000000000000000000000000000000000000000000000000000000000000e0" +
+		"0000000000000000000000000000000000000000000000000000000000000003" +
+		"6f6e650000000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000003" +
+		"74776f0000000000000000000000000000000000000000000000000000000000" +
+		"0000000000000000000000000000000000000000000000000000000000000005" +
+		"7468726565000000000000000000000000000000000000000000000000000000"
+	for i, input := range []string{f, g} {
+		decoded, err := abi.DecodeInput(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		values := make([]any, len(decoded.Args))
+		for j, arg := range decoded.Args {
+			values[j] = arg.Value
+		}
+		data, err := EncodeABIArgs(abi[i].Inputs, values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if encoded := abi[i].Selector() + fmt.Sprintf("%x", data); encoded != input {
+			t.Fatalf("%s encoded as %s", decoded.Signature, encoded)
+		}
+		t.Logf("%s %+v", decoded.Signature, values)
+	}
+}
+
+func TestSignatures(t *testing.T) {
+	if s := MethodSignatures("0xa9059cbb000000000000000000000000"); len(s) != 1 || s[0] != "transfer(address,uint256)" {
+		t.Fatal("transfer selector", s)
+	}
+	if s := EventSignatures([]types.Hash{"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"}); len(s) != 1 || s[0] != "Transfer(address,address,uint256)" {
+		t.Fatal("Transfer topic", s)
+	}
+	signature, err := ParseSignature("swap(uint, (address, uint24)[], bytes)")
+	if err != nil || signature != "swap(uint256,(address,uint24)[],bytes)" {
+		t.Fatal(signature, err)
+	}
+	for _, text := range []string{"swap", "swap(uint7)", "swap((uint256)", "1swap()"} {
+		if _, err := ParseSignature(text); err == nil {
+			t.Fatal(text, "accepted")
+		}
+	}
+	// the returned signatures are a copy, changing them does not change the known ones
+	MethodSignatures("0xa9059cbb")[0] = "changed()"
+	if s := MethodSignatures("0xa9059cbb"); s[0] != "transfer(address,uint256)" {
+		t.Fatal("known signature changed", s)
+	}
+}
+
+// storageStub answers eth_getStorageAt from the slots and eth_call with an empty result
+type storageStub map[string]string
+
+func (s storageStub) CallContract(ctx context.Context, to, data, number any) (types.Bytes, error) {
+	return "0x", nil
+}
+
+func (s storageStub) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	word, ok := s[args[1].(string)]
+	if !ok {
+		word = Big0
+	}
+	*result.(*string) = word
+	return nil
+}
+
+func TestDetectProxy(t *testing.T) {
+	code := "0x363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd5bf3"
+	proxy, err := DetectProxy(storageStub{}, context.Background(), "0x1", types.Address(Addr1), code)
+	if err != nil || proxy == nil || proxy.Kind != "eip1167" || proxy.Implementation != "0xbebebebebebebebebebebebebebebebebebebebe" {
+		t.Fatal("minimal proxy", proxy, err)
+	}
+	slots := storageStub{eip1967ImplementationSlot: "0x000000000000000000000000" + Addr2[2:]}
+	if proxy, err = DetectProxy(slots, context.Background(), "0x1", types.Address(Addr1), "0x6080"); err != nil || proxy == nil || proxy.Kind != "eip1967" || proxy.Implementation != types.Address(Addr2) {
+		t.Fatal("eip1967 proxy", proxy, err)
+	}
+	if proxy, err = DetectProxy(storageStub{}, context.Background(), "0x1", types.Address(Addr1), "0x6080"); err != nil || proxy != nil {
+		t.Fatal("not a proxy", proxy, err)
+	}
+}
+
+// supplyStub answers the eth_call batches with the results or the errors by contract address
+type supplyStub map[types.Address]any
+
+func (s supplyStub) BatchCallContext(ctx context.Context, b []node.BatchElem) error {
+	for i := range b {
+		switch answer := s[b[i].Args[0].(map[string]any)["to"].(types.Address)].(type) {
+		case error:
+			b[i].Error = answer
+		case string:
+			*b[i].Result.(*types.Bytes) = types.Bytes(answer)
+		}
+	}
+	return nil
+}
+
+func TestSetTokenSupplies(t *testing.T) {
+	stub := supplyStub{
+		"0x01": "0x00000000000000000000000000000000000000000000000000000000000003e8",
+		"0x02": errors.New("execution reverted"),
+		"0x03": "0x",
+	}
+	tokens := []*model.Token{{Address: "0x01"}, {Address: "0x02"}, {Address: "0x03"}}
+	if err := SetTokenSupplies(stub, context.Background(), "0x1", tokens); err != nil {
+		t.Fatal(err)
+	}
+	if tokens[0].TotalSupply == nil || *tokens[0].TotalSupply != "1000" || tokens[1].TotalSupply != nil || tokens[2].TotalSupply != nil {
+		t.Fatal("total supplies", tokens[0].TotalSupply, tokens[1].TotalSupply, tokens[2].TotalSupply)
+	}
+	// the node failures are not taken for contracts without totalSupply
+	for _, err := range []error{errors.New("execution aborted (timeout = 5s)"), context.DeadlineExceeded, errors.New("header not found")} {
+		stub["0x02"] = err
+		if SetTokenSupplies(stub, context.Background(), "0x1", tokens) == nil {
+			t.Fatal(err, "dropped")
+		}
+	}
 }
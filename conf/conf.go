@@ -1,10 +1,12 @@
 package conf
 
 import (
+	"fmt"
 	"log"
 	"os"
 	"runtime"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
@@ -12,13 +14,29 @@ import (
 
 // default allocation
 var (
-	ChainUrl   = "http://localhost:8545"
-	ServerAddr = ":3000"
-	Interval   = time.Second
-	Thread     = int64(8 * runtime.NumCPU())
-	MysqlDsn   = "root:123456@tcp(127.0.0.1:3306)/scan"
+	ChainUrl         = "http://localhost:8545"
+	ChainProfile     = "erbie"
+	ServerAddr       = ":3000"
+	Interval         = time.Second
+	Thread           = int64(8 * runtime.NumCPU())
+	BatchSize        = 100
+	Confirmations    = int64(0)
+	IndexUnconfirmed = true
+	MysqlDsn         = "root:123456@tcp(127.0.0.1:3306)/scan"
+	DatabaseDsn      = ""
+	ShutdownTimeout  = 10 * time.Second
+	AdminKey         = ""
+	TrustedProxies   []string
+	RateLimits       = map[string]RateLimit{"*": {Rate: 10, Burst: 20}}
+	DailyQuotas      = map[string]int64{"public": 100000, "partner": 1000000}
 )
 
+// RateLimit token bucket of a route group, Rate requests per second with bursts of Burst requests
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
 func init() {
 	// set log printout to stdout instead of stderr
 	log.SetOutput(os.Stdout)
@@ -33,6 +51,9 @@ func init() {
 	if chainUrl := os.Getenv("CHAIN_URL"); chainUrl != "" {
 		ChainUrl = chainUrl
 	}
+	if chainProfile := os.Getenv("CHAIN_PROFILE"); chainProfile != "" {
+		ChainProfile = chainProfile
+	}
 	if serverAddr := os.Getenv("SERVER_ADDR"); serverAddr != "" {
 		ServerAddr = serverAddr
 	}
@@ -48,7 +69,81 @@ func init() {
 			panic(err)
 		}
 	}
+	if batchSize := os.Getenv("BATCH_SIZE"); batchSize != "" {
+		BatchSize, err = strconv.Atoi(batchSize)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if confirmations := os.Getenv("CONFIRMATIONS"); confirmations != "" {
+		Confirmations, err = strconv.ParseInt(confirmations, 0, 64)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if indexUnconfirmed := os.Getenv("INDEX_UNCONFIRMED"); indexUnconfirmed != "" {
+		IndexUnconfirmed, err = strconv.ParseBool(indexUnconfirmed)
+		if err != nil {
+			panic(err)
+		}
+	}
 	if mysqlDsn := os.Getenv("MYSQL_DSN"); mysqlDsn != "" {
 		MysqlDsn = mysqlDsn
 	}
+	// DATABASE_DSN picks the database by its scheme, MYSQL_DSN is kept for the existing configurations
+	if DatabaseDsn = os.Getenv("DATABASE_DSN"); DatabaseDsn == "" {
+		DatabaseDsn = MysqlDsn
+	}
+	if adminKey := os.Getenv("ADMIN_KEY"); adminKey != "" {
+		AdminKey = adminKey
+	}
+	if shutdownTimeout := os.Getenv("SHUTDOWN_TIMEOUT"); shutdownTimeout != "" {
+		ShutdownTimeout, err = time.ParseDuration(shutdownTimeout)
+		if err != nil {
+			panic(err)
+		}
+	}
+	if trustedProxies := os.Getenv("TRUSTED_PROXIES"); trustedProxies != "" {
+		TrustedProxies = strings.Split(trustedProxies, ",")
+	}
+	// RATE_LIMITS lists path prefix=rate:burst, the * group holds the paths no other prefix matches
+	if rateLimits := os.Getenv("RATE_LIMITS"); rateLimits != "" {
+		RateLimits = make(map[string]RateLimit)
+		for _, group := range strings.Split(rateLimits, ",") {
+			var prefix string
+			var limit RateLimit
+			if prefix, limit, err = parseRateLimit(group); err != nil {
+				panic(err)
+			}
+			RateLimits[prefix] = limit
+		}
+	}
+	// DAILY_QUOTAS lists role=requests, 0 or a missing role is not limited
+	if dailyQuotas := os.Getenv("DAILY_QUOTAS"); dailyQuotas != "" {
+		DailyQuotas = make(map[string]int64)
+		for _, quota := range strings.Split(dailyQuotas, ",") {
+			role, requests, _ := strings.Cut(quota, "=")
+			if DailyQuotas[strings.TrimSpace(role)], err = strconv.ParseInt(strings.TrimSpace(requests), 0, 64); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+func parseRateLimit(group string) (prefix string, limit RateLimit, err error) {
+	prefix, value, ok := strings.Cut(group, "=")
+	rate, burst, ok2 := strings.Cut(value, ":")
+	if !ok || !ok2 {
+		return "", limit, fmt.Errorf("rate limit %q is not prefix=rate:burst", group)
+	}
+	if limit.Rate, err = strconv.ParseFloat(strings.TrimSpace(rate), 64); err != nil {
+		return
+	}
+	if limit.Burst, err = strconv.Atoi(strings.TrimSpace(burst)); err != nil {
+		return
+	}
+	if limit.Rate <= 0 || limit.Burst <= 0 {
+		return "", limit, fmt.Errorf("rate limit %q is not positive", group)
+	}
+	return strings.TrimSpace(prefix), limit, nil
 }
@@ -1,5 +1,4 @@
-// Package docs GENERATED BY SWAG; DO NOT EDIT
-// This file was generated by swaggo/swag
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
 package docs
 
 import "github.com/swaggo/swag"
@@ -103,9 +102,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/block/page": {
+        "/account/{addr}/nfts": {
             "get": {
-                "description": "Query the block list in reverse order of height",
+                "description": "Query the ERC721 tokens owned by the account across all the collections",
                 "consumes": [
                     "application/json"
                 ],
@@ -113,15 +112,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "block"
+                    "token"
                 ],
-                "summary": "query block list",
+                "summary": "query account ERC721 tokens",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "filter block, 1: black hole; 2: penalty; other: all",
-                        "name": "filter",
-                        "in": "query"
+                        "description": "account address",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "string",
@@ -140,7 +140,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.BlocksRes"
+                            "$ref": "#/definitions/service.AccountNFTsRes"
                         }
                     },
                     "400": {
@@ -152,9 +152,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/block/{number}": {
+        "/account/{addr}/tokens": {
             "get": {
-                "description": "specifies the number query block",
+                "description": "Query the token balances of the account",
                 "consumes": [
                     "application/json"
                 ],
@@ -162,23 +162,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "block"
+                    "token"
                 ],
-                "summary": "query block",
+                "summary": "query account tokens",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Block number",
-                        "name": "number",
+                        "description": "account address",
+                        "name": "addr",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Block"
+                            "$ref": "#/definitions/service.AccountTokensRes"
                         }
                     },
                     "400": {
@@ -190,9 +202,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/chart/line": {
-            "get": {
-                "description": "query charts",
+        "/admin/abi/{addr}": {
+            "post": {
+                "description": "Store the ABI JSON of the contract, its transaction input, event logs and custom errors are decoded from then on",
                 "consumes": [
                     "application/json"
                 ],
@@ -200,22 +212,45 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chart"
+                    "admin"
                 ],
-                "summary": "query charts",
+                "summary": "upload contract ABI",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Limit, default 10",
-                        "name": "limit",
+                        "description": "admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "contract address",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "contract name",
+                        "name": "name",
                         "in": "query"
+                    },
+                    {
+                        "description": "ABI JSON array",
+                        "name": "abi",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.LineChartRes"
+                            "$ref": "#/definitions/model.ContractABI"
                         }
                     },
                     "400": {
@@ -227,9 +262,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/chart/nft": {
+        "/admin/reindex": {
             "get": {
-                "description": "query 24h nft growth charts",
+                "description": "Query the progress of the reindex jobs started since the service started, the latest first",
                 "consumes": [
                     "application/json"
                 ],
@@ -237,14 +272,70 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chart"
+                    "admin"
+                ],
+                "summary": "query reindex jobs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    }
                 ],
-                "summary": "query 24h nft growth charts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.NFTChartRes"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/backend.ReindexJob"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Decode and write the stored blocks from..to again in the background, the chain head keeps advancing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "reindex block range",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "first block",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "last block",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backend.ReindexJob"
                         }
                     },
                     "400": {
@@ -256,9 +347,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/chart/tx": {
-            "get": {
-                "description": "query 24h tx growth charts",
+        "/admin/signature": {
+            "post": {
+                "description": "Add function and event text signatures to the built-in signature database, the method and event names of the transactions and logs are looked up in it",
                 "consumes": [
                     "application/json"
                 ],
@@ -266,14 +357,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "chart"
+                    "admin"
+                ],
+                "summary": "add text signatures",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "text signatures",
+                        "name": "signatures",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/service.SignatureReq"
+                            }
+                        }
+                    }
                 ],
-                "summary": "query 24h tx growth charts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.TxChartRes"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Signature"
+                            }
                         }
                     },
                     "400": {
@@ -285,9 +400,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/creator/page": {
+        "/block/page": {
             "get": {
-                "description": "Query the creator list, page",
+                "description": "Query the block list in reverse order of height",
                 "consumes": [
                     "application/json"
                 ],
@@ -295,10 +410,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "creator"
+                    "block"
                 ],
-                "summary": "Query the creator list",
+                "summary": "query block list",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "filter block, 1: black hole; 2: penalty; other: all",
+                        "name": "filter",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Page, default 1",
@@ -313,8 +434,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "sort by conditions, Support database order statement",
-                        "name": "order",
+                        "description": "Cursor of the page, the next of the previous page, takes the place of page",
+                        "name": "cursor",
                         "in": "query"
                     }
                 ],
@@ -322,7 +443,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.CreatorsRes"
+                            "$ref": "#/definitions/service.BlocksRes"
                         }
                     },
                     "400": {
@@ -334,9 +455,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/creator/top": {
+        "/block/{number}": {
             "get": {
-                "description": "Query the top creators",
+                "description": "specifies the number query block",
                 "consumes": [
                     "application/json"
                 ],
@@ -344,25 +465,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "creator"
+                    "block"
                 ],
-                "summary": "Query the top creators",
+                "summary": "query block",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "request number, default 10",
-                        "name": "size",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Block number",
+                        "name": "number",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Creator"
-                            }
+                            "$ref": "#/definitions/model.Block"
                         }
                     },
                     "400": {
@@ -374,9 +493,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/creator/{addr}": {
+        "/chart/line": {
             "get": {
-                "description": "specifies the address to query the creator",
+                "description": "query charts",
                 "consumes": [
                     "application/json"
                 ],
@@ -384,22 +503,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "creator"
+                    "chart"
                 ],
-                "summary": "Query a creator",
+                "summary": "query charts",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "creator address",
-                        "name": "addr",
-                        "in": "path"
+                        "description": "Limit, default 10",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Creator"
+                            "$ref": "#/definitions/service.LineChartRes"
                         }
                     },
                     "400": {
@@ -411,9 +530,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/epoch": {
+        "/chart/nft": {
             "get": {
-                "description": "Query the system NFT period list in reverse order of creation time",
+                "description": "query 24h nft growth charts",
                 "consumes": [
                     "application/json"
                 ],
@@ -421,40 +540,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "system NFT period"
-                ],
-                "summary": "Query the system NFT period list",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "only return the creator's epoch",
-                        "name": "creator",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "sort by conditions, Support database order statement",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Page, default 1",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Page size, default 10",
-                        "name": "page_size",
-                        "in": "query"
-                    }
+                    "chart"
                 ],
+                "summary": "query 24h nft growth charts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.EpochsRes"
+                            "$ref": "#/definitions/service.NFTChartRes"
                         }
                     },
                     "400": {
@@ -466,9 +559,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/epoch/{id}": {
+        "/chart/tx": {
             "get": {
-                "description": "specifies the ID to query the NFT period information of the system, including 16 collection information",
+                "description": "query 24h tx growth charts",
                 "consumes": [
                     "application/json"
                 ],
@@ -476,22 +569,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "system NFT period"
-                ],
-                "summary": "Query system NFT period",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Period ID, current means query the current period",
-                        "name": "id",
-                        "in": "path"
-                    }
+                    "chart"
                 ],
+                "summary": "query 24h tx growth charts",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Epoch"
+                            "$ref": "#/definitions/service.AccountChartRes"
                         }
                     },
                     "400": {
@@ -503,9 +588,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/erb_price": {
+        "/contract/{addr}/abi": {
             "get": {
-                "description": "Query an ERB price, 1ERB=10^18wei, failed to implement the ERB price definition, fixed at 1ERB=0.5USD",
+                "description": "Query the uploaded ABI of the contract",
                 "consumes": [
                     "application/json"
                 ],
@@ -513,22 +598,37 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "extra"
+                    "contract"
+                ],
+                "summary": "query contract ABI",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "contract address",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "query ERB price",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/api.price"
+                            "$ref": "#/definitions/model.ContractABI"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
                         }
                     }
                 }
             }
         },
-        "/exec_sql": {
+        "/contract/{addr}/proxy": {
             "get": {
-                "description": "execute sql statement and return the result, only read",
+                "description": "Query the implementation history of the proxy contract, the latest first, a beacon proxy also lists the upgrades of its beacon. The calls and logs of a proxy without its own ABI are decoded with the ABI of the current implementation",
                 "consumes": [
                     "application/json"
                 ],
@@ -536,21 +636,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "extra"
+                    "contract"
                 ],
-                "summary": "exec sql statement",
+                "summary": "query proxy implementations",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "admin key",
-                        "name": "key",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "sql statement",
-                        "name": "sql",
-                        "in": "query"
+                        "description": "proxy contract address",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -559,7 +654,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "string"
+                                "$ref": "#/definitions/model.ProxyImplementation"
                             }
                         }
                     },
@@ -572,9 +667,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/nft/page": {
-            "get": {
-                "description": "Query the NFT list in reverse order of creation time",
+        "/contract/{addr}/read": {
+            "post": {
+                "description": "Call a view function of the contract with eth_call at the block, encoded and decoded with the given or the uploaded ABI",
                 "consumes": [
                     "application/json"
                 ],
@@ -582,16 +677,57 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NFT"
+                    "contract"
                 ],
-                "summary": "query NFT list",
+                "summary": "read contract",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Owner, if empty, query all",
-                        "name": "owner",
-                        "in": "query"
+                        "description": "contract address",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "function, arguments and block",
+                        "name": "call",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/service.ReadContractReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ReadContractRes"
+                        }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/creator/page": {
+            "get": {
+                "description": "Query the creator list, page",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "creator"
+                ],
+                "summary": "Query the creator list",
+                "parameters": [
                     {
                         "type": "string",
                         "description": "Page, default 1",
@@ -603,13 +739,19 @@ const docTemplate = `{
                         "description": "Page size, default 10",
                         "name": "page_size",
                         "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort by conditions, Support database order statement",
+                        "name": "order",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.NFTsRes"
+                            "$ref": "#/definitions/service.CreatorsRes"
                         }
                     },
                     "400": {
@@ -621,9 +763,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/nft/{addr}": {
+        "/creator/top": {
             "get": {
-                "description": "Query one NFT by address",
+                "description": "Query the top creators",
                 "consumes": [
                     "application/json"
                 ],
@@ -631,23 +773,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NFT"
+                    "creator"
                 ],
-                "summary": "query one NFT",
+                "summary": "Query the top creators",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Address",
-                        "name": "addr",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "description": "request number, default 10",
+                        "name": "size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.NFT"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Creator"
+                            }
                         }
                     },
                     "400": {
@@ -659,9 +803,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/pledge/page": {
+        "/creator/{addr}": {
             "get": {
-                "description": "Query pledge list",
+                "description": "specifies the address to query the creator",
                 "consumes": [
                     "application/json"
                 ],
@@ -669,40 +813,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "block"
+                    "creator"
                 ],
-                "summary": "query pledge list",
+                "summary": "Query a creator",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Page, default 1",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Page size, default 10",
-                        "name": "page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "staker address",
-                        "name": "staker",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "validator address",
-                        "name": "validator",
-                        "in": "query"
+                        "description": "creator address",
+                        "name": "addr",
+                        "in": "path"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.PledgesRes"
+                            "$ref": "#/definitions/model.Creator"
                         }
                     },
                     "400": {
@@ -714,9 +840,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ranking/nft": {
+        "/epoch": {
             "get": {
-                "description": "NFT ranking for a specified time range",
+                "description": "Query the system NFT period list in reverse order of creation time",
                 "consumes": [
                     "application/json"
                 ],
@@ -724,10 +850,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ranking"
+                    "system NFT period"
                 ],
-                "summary": "query NFT ranking",
+                "summary": "Query the system NFT period list",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "only return the creator's epoch",
+                        "name": "creator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort by conditions, Support database order statement",
+                        "name": "order",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Page, default 1",
@@ -745,7 +883,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.RankingNFTRes"
+                            "$ref": "#/definitions/service.EpochsRes"
                         }
                     },
                     "400": {
@@ -757,9 +895,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ranking/snft": {
+        "/epoch/{id}": {
             "get": {
-                "description": "SNFT ranking for a specified time range",
+                "description": "specifies the ID to query the NFT period information of the system, including 16 collection information",
                 "consumes": [
                     "application/json"
                 ],
@@ -767,28 +905,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ranking"
+                    "system NFT period"
                 ],
-                "summary": "query SNFT ranking",
+                "summary": "Query system NFT period",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Page, default 1",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Page size, default 10",
-                        "name": "page_size",
-                        "in": "query"
+                        "description": "Period ID, current means query the current period",
+                        "name": "id",
+                        "in": "path"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.RankingSNFTRes"
+                            "$ref": "#/definitions/model.Epoch"
                         }
                     },
                     "400": {
@@ -800,9 +932,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/ranking/staker": {
+        "/erb_price": {
             "get": {
-                "description": "Staker ranking for a specified time range",
+                "description": "Query an ERB price, 1ERB=10^18wei, failed to implement the ERB price definition, fixed at 1ERB=0.5USD",
                 "consumes": [
                     "application/json"
                 ],
@@ -810,20 +942,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Ranking"
+                    "extra"
                 ],
-                "summary": "query staker ranking",
+                "summary": "query ERB price",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/api.price"
+                        }
+                    }
+                }
+            }
+        },
+        "/exec_sql": {
+            "get": {
+                "description": "execute sql statement and return the result, only read",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "extra"
+                ],
+                "summary": "exec sql statement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Page, default 1",
-                        "name": "page",
-                        "in": "query"
+                        "description": "admin API key",
+                        "name": "X-API-Key",
+                        "in": "header",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Page size, default 10",
-                        "name": "page_size",
+                        "description": "sql statement",
+                        "name": "sql",
                         "in": "query"
                     }
                 ],
@@ -831,7 +987,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.RankingStakerRes"
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
                         }
                     },
                     "400": {
@@ -843,9 +1002,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/reward": {
+        "/nft/page": {
             "get": {
-                "description": "query the reward list in reverse order",
+                "description": "Query the NFT list in reverse order of creation time",
                 "consumes": [
                     "application/json"
                 ],
@@ -853,10 +1012,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reward"
+                    "NFT"
                 ],
-                "summary": "Query the reward list",
+                "summary": "query NFT list",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner, if empty, query all",
+                        "name": "owner",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
                         "description": "Page, default 1",
@@ -874,7 +1039,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.RewardsRes"
+                            "$ref": "#/definitions/service.NFTsRes"
                         }
                     },
                     "400": {
@@ -886,9 +1051,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/reward/{block}": {
+        "/nft/{addr}": {
             "get": {
-                "description": "specifies the block query reward",
+                "description": "Query one NFT by address",
                 "consumes": [
                     "application/json"
                 ],
@@ -896,14 +1061,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "reward"
+                    "NFT"
                 ],
-                "summary": "query reward",
+                "summary": "query one NFT",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Block height",
-                        "name": "block",
+                        "description": "Address",
+                        "name": "addr",
                         "in": "path",
                         "required": true
                     }
@@ -912,10 +1077,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Reward"
-                            }
+                            "$ref": "#/definitions/model.NFT"
                         }
                     },
                     "400": {
@@ -927,9 +1089,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/slashings": {
+        "/pledge/page": {
             "get": {
-                "description": "Query slashing list",
+                "description": "Query pledge list",
                 "consumes": [
                     "application/json"
                 ],
@@ -937,9 +1099,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "extra"
+                    "block"
                 ],
-                "summary": "query slashing list",
+                "summary": "query pledge list",
                 "parameters": [
                     {
                         "type": "string",
@@ -955,69 +1117,22 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "penalty address",
-                        "name": "address",
+                        "description": "staker address",
+                        "name": "staker",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "penalty block number",
-                        "name": "number",
+                        "description": "validator address",
+                        "name": "validator",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "penalty reason, 1: no block; 2: multi-signature; address: validator penalty",
-                        "name": "reason",
-                        "in": "query"
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/service.SlashingsRes"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/service.ErrRes"
-                        }
-                    }
-                }
-            }
-        },
-        "/snft/block": {
-            "get": {
-                "description": "Query the list of SNFT rewards for the specified block",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "NFT"
-                ],
-                "summary": "Query blocks SNFT list",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Block number",
-                        "name": "number",
-                        "in": "query",
-                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.SNFT"
-                            }
+                            "$ref": "#/definitions/service.PledgesRes"
                         }
                     },
                     "400": {
@@ -1029,9 +1144,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/snft/page": {
+        "/ranking/nft": {
             "get": {
-                "description": "Query the SNFT list in reverse order of creation time",
+                "description": "NFT ranking for a specified time range",
                 "consumes": [
                     "application/json"
                 ],
@@ -1039,22 +1154,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NFT"
+                    "Ranking"
                 ],
-                "summary": "query SNFT list",
+                "summary": "query NFT ranking",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "sort, 1:level priority,none:default",
-                        "name": "sort",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Owner, if empty, query all",
-                        "name": "owner",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "description": "Page, default 1",
@@ -1072,7 +1175,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.SNFTsRes"
+                            "$ref": "#/definitions/service.RankingNFTRes"
                         }
                     },
                     "400": {
@@ -1084,9 +1187,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/snft/{addr}": {
+        "/ranking/snft": {
             "get": {
-                "description": "Query one SNFT by address",
+                "description": "SNFT ranking for a specified time range",
                 "consumes": [
                     "application/json"
                 ],
@@ -1094,23 +1197,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NFT"
+                    "Ranking"
                 ],
-                "summary": "query one SNFT",
+                "summary": "query SNFT ranking",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Address",
-                        "name": "addr",
-                        "in": "path",
-                        "required": true
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.SNFTRes"
+                            "$ref": "#/definitions/service.RankingSNFTRes"
                         }
                     },
                     "400": {
@@ -1122,9 +1230,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/snft_meta/page": {
+        "/ranking/staker": {
             "get": {
-                "description": "Query the list of SNFTs with meta information in reverse order of creation time",
+                "description": "Staker ranking for a specified time range",
                 "consumes": [
                     "application/json"
                 ],
@@ -1132,16 +1240,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "NFT"
+                    "Ranking"
                 ],
-                "summary": "Query a list of SNFTs with meta information",
+                "summary": "query staker ranking",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Owner, if empty, query all",
-                        "name": "owner",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "description": "Page, default 1",
@@ -1159,7 +1261,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.SNFTsAndMetaRes"
+                            "$ref": "#/definitions/service.RankingStakerRes"
                         }
                     },
                     "400": {
@@ -1171,9 +1273,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/staker/page": {
+        "/reorg/page": {
             "get": {
-                "description": "Query the list of stakers in reverse order of creation time",
+                "description": "Query the chain reorganizations in reverse order",
                 "consumes": [
                     "application/json"
                 ],
@@ -1181,16 +1283,10 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Staker"
+                    "reorg"
                 ],
-                "summary": "Query the list of stakers",
+                "summary": "query reorg list",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "sort by conditions, Support database order statement",
-                        "name": "order",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
                         "description": "Page, default 1",
@@ -1208,7 +1304,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.StakersRes"
+                            "$ref": "#/definitions/service.ReorgsRes"
                         }
                     },
                     "400": {
@@ -1220,9 +1316,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/staker/{addr}": {
+        "/reorg/{id}": {
             "get": {
-                "description": "Query staker by address",
+                "description": "Query the chain reorganization with its orphaned transactions",
                 "consumes": [
                     "application/json"
                 ],
@@ -1230,14 +1326,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Staker"
+                    "reorg"
                 ],
-                "summary": "query staker",
+                "summary": "query reorg",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "staker address",
-                        "name": "addr",
+                        "description": "reorg id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1246,36 +1342,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Staker"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/service.ErrRes"
-                        }
-                    }
-                }
-            }
-        },
-        "/stats": {
-            "get": {
-                "description": "Query the total number of blocks, transactions, accounts, etc.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "block"
-                ],
-                "summary": "query some stats data",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/model.Stats"
+                            "$ref": "#/definitions/service.ReorgRes"
                         }
                     },
                     "400": {
@@ -1287,9 +1354,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/transaction/erbie/page": {
+        "/reward": {
             "get": {
-                "description": "query erbie transaction list in reverse order",
+                "description": "query the reward list in reverse order",
                 "consumes": [
                     "application/json"
                 ],
@@ -1297,9 +1364,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
+                    "reward"
                 ],
-                "summary": "query erbie transaction list",
+                "summary": "Query the reward list",
                 "parameters": [
                     {
                         "type": "string",
@@ -1315,32 +1382,8 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Block number, if empty, query all",
-                        "name": "number",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Specify the period id",
-                        "name": "epoch",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "nft or snft address, if empty, query all",
-                        "name": "address",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Account address, if empty, query all",
-                        "name": "account",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "erbie tx type,supports multiple types,if empty, query all",
-                        "name": "types",
+                        "description": "Cursor of the page, the next of the previous page, takes the place of page",
+                        "name": "cursor",
                         "in": "query"
                     }
                 ],
@@ -1348,7 +1391,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.ErbiesRes"
+                            "$ref": "#/definitions/service.RewardsRes"
                         }
                     },
                     "400": {
@@ -1360,21 +1403,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/transaction/erbie/{hash}": {
+        "/reward/{block}": {
             "get": {
-                "description": "specifies the hash query internal transaction",
+                "description": "specifies the block query reward",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
+                    "reward"
                 ],
-                "summary": "query erbie transaction list",
+                "summary": "query reward",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "tx hash",
-                        "name": "hash",
+                        "description": "Block height",
+                        "name": "block",
                         "in": "path",
                         "required": true
                     }
@@ -1383,7 +1429,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Erbie"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Reward"
+                            }
                         }
                     },
                     "400": {
@@ -1395,26 +1444,30 @@ const docTemplate = `{
                 }
             }
         },
-        "/transaction/internal/page": {
+        "/search": {
             "get": {
-                "description": "query internal transaction list in reverse order",
+                "description": "Classify the query as a block number, a transaction or block hash, an address, an SNFT address or an epoch ID and look for it, any other query matches the contract names and symbols by prefix",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
+                    "search"
                 ],
-                "summary": "query internal transaction list",
+                "summary": "search",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Page, default 1",
-                        "name": "page",
-                        "in": "query"
+                        "description": "block number, hash, address, SNFT address, epoch ID, or name or symbol prefix",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Page size, default 10",
+                        "description": "maximum number of names and symbols matched, default 10",
                         "name": "page_size",
                         "in": "query"
                     }
@@ -1423,7 +1476,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/service.InternalTxsRes"
+                            "$ref": "#/definitions/service.SearchRes"
                         }
                     },
                     "400": {
@@ -1435,20 +1488,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/transaction/internal/{hash}": {
+        "/signature/{hash}": {
             "get": {
-                "description": "specifies the hash query internal transaction",
+                "description": "Query the known text signatures of a 4 bytes function selector or 32 bytes event topic, more than one when it is ambiguous",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
+                    "contract"
                 ],
-                "summary": "query internal transaction list",
+                "summary": "query text signatures",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "tx hash",
+                        "description": "function selector or event topic",
                         "name": "hash",
                         "in": "path",
                         "required": true
@@ -1460,22 +1516,16 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.InternalTx"
+                                "type": "string"
                             }
                         }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/service.ErrRes"
-                        }
                     }
                 }
             }
         },
-        "/transaction/page": {
+        "/slashings": {
             "get": {
-                "description": "query transaction list in reverse order",
+                "description": "Query slashing list",
                 "consumes": [
                     "application/json"
                 ],
@@ -1483,9 +1533,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "transaction"
+                    "extra"
                 ],
-                "summary": "query transaction list",
+                "summary": "query slashing list",
                 "parameters": [
                     {
                         "type": "string",
@@ -1501,14 +1551,1174 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Block number, if empty, query all",
-                        "name": "number",
+                        "description": "penalty address",
+                        "name": "address",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Account address, if empty, query all",
-                        "name": "addr",
+                        "description": "penalty block number",
+                        "name": "number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "penalty reason, 1: no block; 2: multi-signature; address: validator penalty",
+                        "name": "reason",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.SlashingsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/snft/block": {
+            "get": {
+                "description": "Query the list of SNFT rewards for the specified block",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT"
+                ],
+                "summary": "Query blocks SNFT list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Block number",
+                        "name": "number",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SNFT"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/snft/page": {
+            "get": {
+                "description": "Query the SNFT list in reverse order of creation time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT"
+                ],
+                "summary": "query SNFT list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sort, 1:level priority,none:default",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Owner, if empty, query all",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.SNFTsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/snft/{addr}": {
+            "get": {
+                "description": "Query one SNFT by address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT"
+                ],
+                "summary": "query one SNFT",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Address",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.SNFTRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/snft_meta/page": {
+            "get": {
+                "description": "Query the list of SNFTs with meta information in reverse order of creation time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "NFT"
+                ],
+                "summary": "Query a list of SNFTs with meta information",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Owner, if empty, query all",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.SNFTsAndMetaRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/staker/page": {
+            "get": {
+                "description": "Query the list of stakers in reverse order of creation time",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staker"
+                ],
+                "summary": "Query the list of stakers",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "sort by conditions, Support database order statement",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.StakersRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/staker/{addr}": {
+            "get": {
+                "description": "Query staker by address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Staker"
+                ],
+                "summary": "query staker",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "staker address",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Staker"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Query the total number of blocks, transactions, accounts, etc.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "block"
+                ],
+                "summary": "query some stats data",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Stats"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/page": {
+            "get": {
+                "description": "Query the ERC20, ERC721 and ERC1155 token contracts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "token"
+                ],
+                "summary": "query token list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token type, ERC20, ERC721 or ERC1155, default all",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "sort by holderCount, transferCount, totalSupply or blockNumber, optionally followed by asc or desc, default holderCount desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.TokensRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/{addr}": {
+            "get": {
+                "description": "Query the token contract with its decimals, total supply, holder and transfer counts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "token"
+                ],
+                "summary": "query token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token contract address",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Token"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/{addr}/holders": {
+            "get": {
+                "description": "Query the holders of the token by balance, with their share of the total supply",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "token"
+                ],
+                "summary": "query token holders",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token contract address",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ERC1155 token ID, default all",
+                        "name": "token_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.TokenHoldersRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/{addr}/inventory": {
+            "get": {
+                "description": "Query the tokens of the ERC721 collection that are not burnt, with their owners",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "token"
+                ],
+                "summary": "query ERC721 collection tokens",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token contract address",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "owner address, default all",
+                        "name": "owner",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.InventoryRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/{addr}/nft/{id}": {
+            "get": {
+                "description": "Query the owner of the ERC721 token and all its transfers since the mint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "token"
+                ],
+                "summary": "query ERC721 token",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "token contract address",
+                        "name": "addr",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "token ID, decimal",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ERC721TokenRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/erbie/page": {
+            "get": {
+                "description": "query erbie transaction list in reverse order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "query erbie transaction list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the next of the previous page, takes the place of page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Block number, if empty, query all",
+                        "name": "number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Specify the period id",
+                        "name": "epoch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "nft or snft address, if empty, query all",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account address, if empty, query all",
+                        "name": "account",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "erbie tx type,supports multiple types,if empty, query all",
+                        "name": "types",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErbiesRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/erbie/{hash}": {
+            "get": {
+                "description": "specifies the hash query internal transaction",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "query erbie transaction list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tx hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Erbie"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/erc1155/page": {
+            "get": {
+                "description": "query the ERC1155 transfer events in reverse order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "query ERC1155 transfer list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the next of the previous page, takes the place of page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token contract address, if empty, query all",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sender address, if empty, query all",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Receiver address, if empty, query all",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sender or receiver address, if empty, query all",
+                        "name": "addr",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction hash, if empty, query all",
+                        "name": "tx_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First block number, if empty, from the genesis",
+                        "name": "from_block",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last block number, if empty, to the head",
+                        "name": "to_block",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ERC1155TransfersRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/erc20/page": {
+            "get": {
+                "description": "query the ERC20 transfer events in reverse order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "query ERC20 transfer list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the next of the previous page, takes the place of page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token contract address, if empty, query all",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sender address, if empty, query all",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Receiver address, if empty, query all",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sender or receiver address, if empty, query all",
+                        "name": "addr",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction hash, if empty, query all",
+                        "name": "tx_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First block number, if empty, from the genesis",
+                        "name": "from_block",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last block number, if empty, to the head",
+                        "name": "to_block",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ERC20TransfersRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/erc721/page": {
+            "get": {
+                "description": "query the ERC721 transfer events in reverse order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "query ERC721 transfer list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the next of the previous page, takes the place of page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Token contract address, if empty, query all",
+                        "name": "address",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sender address, if empty, query all",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Receiver address, if empty, query all",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sender or receiver address, if empty, query all",
+                        "name": "addr",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Transaction hash, if empty, query all",
+                        "name": "tx_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First block number, if empty, from the genesis",
+                        "name": "from_block",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last block number, if empty, to the head",
+                        "name": "to_block",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.ERC721TransfersRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/internal/page": {
+            "get": {
+                "description": "query internal transaction list in reverse order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "query internal transaction list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the next of the previous page, takes the place of page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.InternalTxsRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/internal/{hash}": {
+            "get": {
+                "description": "specifies the hash query internal transaction",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "query internal transaction list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tx hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.InternalTx"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/internal/{hash}/tree": {
+            "get": {
+                "description": "specifies the hash query the call tree of the transaction, the root is the transaction itself",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "query internal transaction call tree",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tx hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.CallFrame"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/orphaned/{hash}": {
+            "get": {
+                "description": "Query whether the transaction was ever in an orphaned block, and whether it is in the current chain",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "reorg"
+                ],
+                "summary": "query orphaned transaction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "tx hash",
+                        "name": "hash",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/service.OrphanedTxRes"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/service.ErrRes"
+                        }
+                    }
+                }
+            }
+        },
+        "/transaction/page": {
+            "get": {
+                "description": "query transaction list in reverse order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "transaction"
+                ],
+                "summary": "query transaction list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Page, default 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, default 10",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page, the next of the previous page, takes the place of page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Block number, if empty, query all",
+                        "name": "number",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account address, if empty, query all",
+                        "name": "addr",
                         "in": "query"
                     },
                     {
@@ -1560,7 +2770,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Transaction"
+                            "$ref": "#/definitions/service.TransactionRes"
                         }
                     },
                     "400": {
@@ -1600,7 +2810,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.EventLog"
+                                "$ref": "#/definitions/service.EventLogRes"
                             }
                         }
                     },
@@ -1738,35 +2948,104 @@ const docTemplate = `{
                 }
             }
         },
+        "backend.ReindexJob": {
+            "type": "object",
+            "properties": {
+                "current": {
+                    "description": "next block to reindex",
+                    "type": "integer"
+                },
+                "done": {
+                    "description": "whether the job has ended",
+                    "type": "boolean"
+                },
+                "error": {
+                    "description": "error that ended the job",
+                    "type": "string"
+                },
+                "finished": {
+                    "description": "end timestamp",
+                    "type": "integer"
+                },
+                "from": {
+                    "description": "first block of the range",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "job id",
+                    "type": "integer"
+                },
+                "started": {
+                    "description": "start timestamp",
+                    "type": "integer"
+                },
+                "to": {
+                    "description": "last block of the range",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AccessTuple": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "accessed address",
+                    "type": "string"
+                },
+                "storageKeys": {
+                    "description": "accessed storage keys",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "model.Block": {
             "type": "object",
             "properties": {
+                "baseFeePerGas": {
+                    "description": "EIP-1559 base fee, unit wei, empty before london",
+                    "type": "string"
+                },
+                "burntFees": {
+                    "description": "base fee times the gas used, unit wei",
+                    "type": "string"
+                },
+                "confirmations": {
+                    "description": "number of blocks from this block to the chain head, both included",
+                    "type": "integer"
+                },
                 "difficulty": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "difficulty",
                     "type": "integer"
                 },
                 "extraData": {
                     "description": "Extra data",
                     "type": "string"
                 },
+                "finalized": {
+                    "description": "whether the block has the confirmations required to be final",
+                    "type": "boolean"
+                },
                 "gasLimit": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "Gas limit",
                     "type": "integer"
                 },
                 "gasUsed": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "Gas consumption",
                     "type": "integer"
                 },
                 "hash": {
-                    "description": "create transaction",
+                    "description": "Hash",
                     "type": "string"
                 },
                 "miner": {
-                    "description": "address",
+                    "description": "miner",
                     "type": "string"
                 },
                 "mixHash": {
-                    "description": "create transaction",
+                    "description": "Mixed hash",
                     "type": "string"
                 },
                 "nonce": {
@@ -1774,18 +3053,17 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "number": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "block number",
                     "type": "integer"
                 },
                 "parentHash": {
-                    "description": "create transaction",
+                    "description": "parent block hash",
                     "type": "string"
                 },
                 "proof": {
                     "description": "slash validator proof, multi-signature block hash",
                     "type": "array",
                     "items": {
-                        "description": "create transaction",
                         "type": "string"
                     }
                 },
@@ -1793,90 +3071,264 @@ const docTemplate = `{
                     "description": "black hole block proposers address",
                     "type": "array",
                     "items": {
-                        "description": "address",
                         "type": "string"
                     }
                 },
                 "receiptsRoot": {
-                    "description": "create transaction",
+                    "description": "Transaction receipt root hash",
                     "type": "string"
                 },
                 "sha3Uncles": {
-                    "description": "create transaction",
+                    "description": "Uncle root hash",
                     "type": "string"
                 },
                 "size": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "size",
                     "type": "integer"
                 },
                 "stateRoot": {
-                    "description": "create transaction",
+                    "description": "World tree root hash",
                     "type": "string"
                 },
                 "timestamp": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "timestamp",
                     "type": "integer"
                 },
                 "totalDifficulty": {
-                    "description": "The total amount of coins in the chain",
+                    "description": "total difficulty",
+                    "type": "string"
+                },
+                "totalFees": {
+                    "description": "sum of the transaction fees, unit wei",
+                    "type": "string"
+                },
+                "totalTransaction": {
+                    "description": "number of transactions",
+                    "type": "integer"
+                },
+                "transactionsRoot": {
+                    "description": "transaction root hash",
+                    "type": "string"
+                },
+                "uncles": {
+                    "description": "Uncle block hash",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.ContractABI": {
+            "type": "object",
+            "properties": {
+                "abi": {
+                    "description": "ABI JSON",
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "address": {
+                    "description": "contract address",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "contract name",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "upload time",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.Creator": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "account address",
+                    "type": "string"
+                },
+                "count": {
+                    "description": "selected count",
+                    "type": "integer"
+                },
+                "lastEpoch": {
+                    "description": "last selected for the epoch",
+                    "type": "string"
+                },
+                "lastNumber": {
+                    "description": "last selected for the block number",
+                    "type": "integer"
+                },
+                "lastTime": {
+                    "description": "last selected for the timestamp",
+                    "type": "integer"
+                },
+                "number": {
+                    "description": "be a Creator block number",
+                    "type": "integer"
+                },
+                "profit": {
+                    "description": "royalty profit",
+                    "type": "string"
+                },
+                "reward": {
+                    "description": "vote profit",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "be a Creator Time",
+                    "type": "integer"
+                }
+            }
+        },
+        "model.ERC1155Transfer": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Contract address",
+                    "type": "string"
+                },
+                "batchIndex": {
+                    "description": "The position in the TransferBatch event, 0 for TransferSingle",
+                    "type": "integer"
+                },
+                "blockNumber": {
+                    "description": "block number",
+                    "type": "integer"
+                },
+                "from": {
+                    "description": "Originating address",
+                    "type": "string"
+                },
+                "logIndex": {
+                    "description": "The serial number of the log in the block",
+                    "type": "integer"
+                },
+                "operator": {
+                    "description": "operator address",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "The event stamp of the block it is in",
+                    "type": "integer"
+                },
+                "to": {
+                    "description": "Receive address",
+                    "type": "string"
+                },
+                "tokenId": {
+                    "description": "Token ID",
+                    "type": "string"
+                },
+                "txHash": {
+                    "description": "The transaction hash",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Token amount",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ERC20Transfer": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "Contract address",
+                    "type": "string"
+                },
+                "blockNumber": {
+                    "description": "block number",
+                    "type": "integer"
+                },
+                "from": {
+                    "description": "Originating address",
+                    "type": "string"
+                },
+                "logIndex": {
+                    "description": "The serial number of the log in the block",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "The event stamp of the block it is in",
+                    "type": "integer"
+                },
+                "to": {
+                    "description": "Receive address",
+                    "type": "string"
+                },
+                "txHash": {
+                    "description": "The transaction hash",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "amount",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ERC721Token": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "token contract address",
                     "type": "string"
                 },
-                "totalTransaction": {
-                    "description": "transaction random number, transaction volume",
+                "blockNumber": {
+                    "description": "block number of the last transfer",
                     "type": "integer"
                 },
-                "transactionsRoot": {
-                    "description": "create transaction",
+                "owner": {
+                    "description": "owner, the zero address when burnt",
                     "type": "string"
                 },
-                "uncles": {
-                    "description": "Uncle block hash",
-                    "type": "array",
-                    "items": {
-                        "description": "create transaction",
-                        "type": "string"
-                    }
+                "tokenId": {
+                    "description": "Token ID",
+                    "type": "string"
+                },
+                "txHash": {
+                    "description": "transaction hash of the last transfer",
+                    "type": "string"
                 }
             }
         },
-        "model.Creator": {
+        "model.ERC721Transfer": {
             "type": "object",
             "properties": {
                 "address": {
-                    "description": "account address",
+                    "description": "Contract address",
                     "type": "string"
                 },
-                "count": {
-                    "description": "selected count",
+                "blockNumber": {
+                    "description": "block number",
                     "type": "integer"
                 },
-                "lastEpoch": {
-                    "description": "last selected for the epoch",
+                "from": {
+                    "description": "Originating address",
                     "type": "string"
                 },
-                "lastNumber": {
-                    "description": "last selected for the block number",
-                    "type": "integer"
-                },
-                "lastTime": {
-                    "description": "last selected for the timestamp",
+                "logIndex": {
+                    "description": "The serial number of the log in the block",
                     "type": "integer"
                 },
-                "number": {
-                    "description": "be a Creator block number",
+                "timestamp": {
+                    "description": "The event stamp of the block it is in",
                     "type": "integer"
                 },
-                "profit": {
-                    "description": "royalty profit",
+                "to": {
+                    "description": "Receive address",
                     "type": "string"
                 },
-                "reward": {
-                    "description": "vote profit",
+                "tokenId": {
+                    "description": "Token ID",
                     "type": "string"
                 },
-                "timestamp": {
-                    "description": "be a Creator Time",
-                    "type": "integer"
+                "txHash": {
+                    "description": "The transaction hash",
+                    "type": "string"
                 }
             }
         },
@@ -1960,6 +3412,10 @@ const docTemplate = `{
                     "description": "seller or caller address",
                     "type": "string"
                 },
+                "proxy": {
+                    "description": "It's validator's proxy address to run a node",
+                    "type": "string"
+                },
                 "royalty_rate": {
                     "description": "for the creator royalty rate",
                     "type": "integer"
@@ -1986,72 +3442,63 @@ const docTemplate = `{
                 }
             }
         },
-        "model.EventLog": {
+        "model.InternalTx": {
             "type": "object",
             "properties": {
-                "address": {
-                    "description": "address",
-                    "type": "string"
-                },
                 "blockNumber": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "block number",
                     "type": "integer"
                 },
-                "data": {
-                    "description": "data",
-                    "type": "string"
-                },
-                "logIndex": {
-                    "description": "transaction random number, transaction volume",
+                "depth": {
+                    "description": "call depth, 1: called by the transaction",
                     "type": "integer"
                 },
-                "removed": {
-                    "description": "whether to remove",
-                    "type": "boolean"
-                },
-                "topics": {
-                    "description": "topic",
-                    "type": "array",
-                    "items": {
-                        "description": "create transaction",
-                        "type": "string"
-                    }
-                },
-                "transactionHash": {
-                    "description": "create transaction",
+                "error": {
+                    "description": "exec error",
                     "type": "string"
-                }
-            }
-        },
-        "model.InternalTx": {
-            "type": "object",
-            "properties": {
+                },
                 "from": {
-                    "description": "address",
+                    "description": "Originating address",
                     "type": "string"
                 },
                 "gas": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "Gas",
+                    "type": "integer"
+                },
+                "gasUsed": {
+                    "description": "Gas consumption",
                     "type": "integer"
                 },
                 "index": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "call index, in call order",
                     "type": "integer"
                 },
+                "input": {
+                    "description": "call data",
+                    "type": "string"
+                },
                 "op": {
                     "description": "Operation",
                     "type": "string"
                 },
+                "output": {
+                    "description": "return data",
+                    "type": "string"
+                },
+                "parentIndex": {
+                    "description": "index of the calling call, -1: the transaction",
+                    "type": "integer"
+                },
                 "to": {
-                    "description": "address",
+                    "description": "Receive address",
                     "type": "string"
                 },
                 "txHash": {
-                    "description": "create transaction",
+                    "description": "The transaction",
                     "type": "string"
                 },
                 "value": {
-                    "description": "The total amount of coins in the chain",
+                    "description": "Amount, unit wei",
                     "type": "string"
                 }
             }
@@ -2101,6 +3548,157 @@ const docTemplate = `{
                 }
             }
         },
+        "model.OrphanedTx": {
+            "type": "object",
+            "properties": {
+                "accessList": {
+                    "description": "EIP-2930 access list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AccessTuple"
+                    }
+                },
+                "blockHash": {
+                    "description": "Block Hash",
+                    "type": "string"
+                },
+                "blockNumber": {
+                    "description": "block number",
+                    "type": "integer"
+                },
+                "chainId": {
+                    "description": "chain id, empty for legacy transactions before EIP-155",
+                    "type": "integer"
+                },
+                "confirmations": {
+                    "description": "number of blocks from the block of the transaction to the chain head, both included",
+                    "type": "integer"
+                },
+                "contractAddress": {
+                    "description": "The created contract address",
+                    "type": "string"
+                },
+                "cumulativeGasUsed": {
+                    "description": "Cumulative gas consumption",
+                    "type": "integer"
+                },
+                "effectiveGasPrice": {
+                    "description": "gas price actually paid, from the receipt",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "exec error",
+                    "type": "string"
+                },
+                "finalized": {
+                    "description": "whether the block of the transaction has the confirmations required to be final",
+                    "type": "boolean"
+                },
+                "from": {
+                    "description": "Send address",
+                    "type": "string"
+                },
+                "gas": {
+                    "description": "fuel",
+                    "type": "integer"
+                },
+                "gasPrice": {
+                    "description": "Gas price, the fee cap for EIP-1559 transactions",
+                    "type": "string"
+                },
+                "gasUsed": {
+                    "description": "Gas consumption",
+                    "type": "integer"
+                },
+                "hash": {
+                    "description": "Hash",
+                    "type": "string"
+                },
+                "input": {
+                    "description": "Additional input data, contract call encoded data",
+                    "type": "string"
+                },
+                "maxFeePerGas": {
+                    "description": "EIP-1559 fee cap, unit wei",
+                    "type": "string"
+                },
+                "maxPriorityFeePerGas": {
+                    "description": "EIP-1559 tip cap, unit wei",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "text signature of the called function, best effort from the known selectors",
+                    "type": "string"
+                },
+                "methodCandidates": {
+                    "description": "all the known text signatures when the selector is ambiguous",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nonce": {
+                    "description": "Random number, the number of transactions initiated by the account",
+                    "type": "integer"
+                },
+                "panicCode": {
+                    "description": "Panic(uint256) code",
+                    "type": "integer"
+                },
+                "r": {
+                    "description": "signature R",
+                    "type": "string"
+                },
+                "reorgId": {
+                    "description": "reorg id",
+                    "type": "integer"
+                },
+                "revertData": {
+                    "description": "return data of the failed transaction",
+                    "type": "string"
+                },
+                "revertReason": {
+                    "description": "decoded revert reason, Error(string), panic description or custom error",
+                    "type": "string"
+                },
+                "s": {
+                    "description": "signature S",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status, 1: success; 0: failure",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "The event stamp of the block it is in",
+                    "type": "integer"
+                },
+                "to": {
+                    "description": "Receive address",
+                    "type": "string"
+                },
+                "transactionIndex": {
+                    "description": "The serial number in the block",
+                    "type": "integer"
+                },
+                "txFee": {
+                    "description": "gas used times the effective gas price, unit wei",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "transaction type, 0: legacy; 1: EIP-2930; 2: EIP-1559",
+                    "type": "integer"
+                },
+                "v": {
+                    "description": "signature V, y parity for typed transactions",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Amount, unit wei",
+                    "type": "string"
+                }
+            }
+        },
         "model.Pledge": {
             "type": "object",
             "properties": {
@@ -2116,17 +3714,98 @@ const docTemplate = `{
                     "description": "staker address",
                     "type": "string"
                 },
-                "timestamp": {
-                    "description": "latest time",
+                "timestamp": {
+                    "description": "latest time",
+                    "type": "integer"
+                },
+                "tx_hash": {
+                    "description": "the transaction created",
+                    "type": "string"
+                },
+                "validator": {
+                    "description": "validator address",
+                    "type": "string"
+                }
+            }
+        },
+        "model.ProxyImplementation": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "proxy contract, or upgradeable beacon",
+                    "type": "string"
+                },
+                "beacon": {
+                    "description": "beacon of the eip1967-beacon proxy",
+                    "type": "string"
+                },
+                "blockNumber": {
+                    "description": "block of the creation or the upgrade",
+                    "type": "integer"
+                },
+                "implementation": {
+                    "description": "implementation contract",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "eip1967, eip1967-beacon, eip1822, eip1167, or beacon for the upgradeable beacon",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "block time",
+                    "type": "integer"
+                },
+                "txHash": {
+                    "description": "creation or upgrade transaction",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Reorg": {
+            "type": "object",
+            "properties": {
+                "blocks": {
+                    "description": "orphaned block hashes, in height order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "depth": {
+                    "description": "number of orphaned blocks",
+                    "type": "integer"
+                },
+                "forkNumber": {
+                    "description": "number of the last block common to both chains",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "reorg id",
+                    "type": "integer"
+                },
+                "newHash": {
+                    "description": "hash of the block of the new chain that revealed the reorg",
+                    "type": "string"
+                },
+                "newHead": {
+                    "description": "number of the block of the new chain that revealed the reorg",
+                    "type": "integer"
+                },
+                "oldHash": {
+                    "description": "hash of the stored head before the reorg",
+                    "type": "string"
+                },
+                "oldHead": {
+                    "description": "number of the stored head before the reorg",
                     "type": "integer"
                 },
-                "tx_hash": {
-                    "description": "the transaction created",
-                    "type": "string"
+                "timestamp": {
+                    "description": "time the reorg was found",
+                    "type": "integer"
                 },
-                "validator": {
-                    "description": "validator address",
-                    "type": "string"
+                "txCount": {
+                    "description": "number of orphaned transactions",
+                    "type": "integer"
                 }
             }
         },
@@ -2196,19 +3875,40 @@ const docTemplate = `{
                 }
             }
         },
+        "model.Signature": {
+            "type": "object",
+            "properties": {
+                "hash": {
+                    "description": "4 bytes selector of the function or topic of the event",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "function or event",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "canonical text signature, e.g. transfer(address,uint256)",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "upload time",
+                    "type": "integer"
+                }
+            }
+        },
         "model.Slashing": {
             "type": "object",
             "properties": {
                 "address": {
-                    "description": "address",
+                    "description": "account address, validator or staker",
                     "type": "string"
                 },
                 "amount": {
-                    "description": "The total amount of coins in the chain",
+                    "description": "penalty amount, unit wei",
                     "type": "string"
                 },
                 "block_number": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "block number",
                     "type": "integer"
                 },
                 "reason": {
@@ -2216,7 +3916,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "weight": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "weight after penalty",
                     "type": "integer"
                 }
             }
@@ -2269,6 +3969,10 @@ const docTemplate = `{
                     "description": "average block time, ms",
                     "type": "integer"
                 },
+                "chainHead": {
+                    "description": "latest block number of the chain node",
+                    "type": "integer"
+                },
                 "chainId": {
                     "description": "chain id",
                     "type": "integer"
@@ -2404,78 +4108,217 @@ const docTemplate = `{
                 "totalValidatorOnline": {
                     "description": "Total amount of validator online",
                     "type": "integer"
+                },
+                "validatorTotalPledge": {
+                    "description": "Total amount of validator pledge",
+                    "type": "string"
+                }
+            }
+        },
+        "model.Token": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "contract address",
+                    "type": "string"
+                },
+                "blockNumber": {
+                    "description": "created block number",
+                    "type": "integer"
+                },
+                "createdTx": {
+                    "description": "create transaction",
+                    "type": "string"
+                },
+                "creator": {
+                    "description": "the creator, empty for the genesis contracts",
+                    "type": "string"
+                },
+                "decimals": {
+                    "description": "ERC20 decimals, empty when not implemented",
+                    "type": "integer"
+                },
+                "holderCount": {
+                    "description": "number of addresses holding the token",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "name",
+                    "type": "string"
+                },
+                "supplyNumber": {
+                    "description": "block number the total supply was read at",
+                    "type": "integer"
+                },
+                "symbol": {
+                    "description": "symbol",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "created timestamp",
+                    "type": "integer"
+                },
+                "totalSupply": {
+                    "description": "total supply, empty when not implemented",
+                    "type": "string"
+                },
+                "transferCount": {
+                    "description": "number of transfer events",
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "contract type, ERC20, ERC721, ERC1155",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.ContractType"
+                        }
+                    ]
                 }
             }
         },
         "model.Transaction": {
             "type": "object",
             "properties": {
+                "accessList": {
+                    "description": "EIP-2930 access list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AccessTuple"
+                    }
+                },
                 "blockHash": {
-                    "description": "create transaction",
+                    "description": "Block Hash",
                     "type": "string"
                 },
                 "blockNumber": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "block number",
+                    "type": "integer"
+                },
+                "chainId": {
+                    "description": "chain id, empty for legacy transactions before EIP-155",
+                    "type": "integer"
+                },
+                "confirmations": {
+                    "description": "number of blocks from the block of the transaction to the chain head, both included",
                     "type": "integer"
                 },
                 "contractAddress": {
-                    "description": "address",
+                    "description": "The created contract address",
                     "type": "string"
                 },
                 "cumulativeGasUsed": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "Cumulative gas consumption",
                     "type": "integer"
                 },
+                "effectiveGasPrice": {
+                    "description": "gas price actually paid, from the receipt",
+                    "type": "string"
+                },
                 "error": {
                     "description": "exec error",
                     "type": "string"
                 },
+                "finalized": {
+                    "description": "whether the block of the transaction has the confirmations required to be final",
+                    "type": "boolean"
+                },
                 "from": {
-                    "description": "address",
+                    "description": "Send address",
                     "type": "string"
                 },
                 "gas": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "fuel",
                     "type": "integer"
                 },
                 "gasPrice": {
-                    "description": "transaction random number, transaction volume",
-                    "type": "integer"
+                    "description": "Gas price, the fee cap for EIP-1559 transactions",
+                    "type": "string"
                 },
                 "gasUsed": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "Gas consumption",
                     "type": "integer"
                 },
                 "hash": {
-                    "description": "create transaction",
+                    "description": "Hash",
                     "type": "string"
                 },
                 "input": {
                     "description": "Additional input data, contract call encoded data",
                     "type": "string"
                 },
+                "maxFeePerGas": {
+                    "description": "EIP-1559 fee cap, unit wei",
+                    "type": "string"
+                },
+                "maxPriorityFeePerGas": {
+                    "description": "EIP-1559 tip cap, unit wei",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "text signature of the called function, best effort from the known selectors",
+                    "type": "string"
+                },
+                "methodCandidates": {
+                    "description": "all the known text signatures when the selector is ambiguous",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "nonce": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "Random number, the number of transactions initiated by the account",
+                    "type": "integer"
+                },
+                "panicCode": {
+                    "description": "Panic(uint256) code",
                     "type": "integer"
                 },
+                "r": {
+                    "description": "signature R",
+                    "type": "string"
+                },
+                "revertData": {
+                    "description": "return data of the failed transaction",
+                    "type": "string"
+                },
+                "revertReason": {
+                    "description": "decoded revert reason, Error(string), panic description or custom error",
+                    "type": "string"
+                },
+                "s": {
+                    "description": "signature S",
+                    "type": "string"
+                },
                 "status": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "Status, 1: success; 0: failure",
                     "type": "integer"
                 },
                 "timestamp": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "The event stamp of the block it is in",
                     "type": "integer"
                 },
                 "to": {
-                    "description": "address",
+                    "description": "Receive address",
                     "type": "string"
                 },
                 "transactionIndex": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "The serial number in the block",
+                    "type": "integer"
+                },
+                "txFee": {
+                    "description": "gas used times the effective gas price, unit wei",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "transaction type, 0: legacy; 1: EIP-2930; 2: EIP-1559",
                     "type": "integer"
                 },
+                "v": {
+                    "description": "signature V, y parity for typed transactions",
+                    "type": "string"
+                },
                 "value": {
-                    "description": "The total amount of coins in the chain",
+                    "description": "Amount, unit wei",
                     "type": "string"
                 }
             }
@@ -2529,6 +4372,68 @@ const docTemplate = `{
                 }
             }
         },
+        "service.AccountChartRes": {
+            "type": "object",
+            "properties": {
+                "hour": {
+                    "description": "hour",
+                    "type": "integer"
+                },
+                "num": {
+                    "description": "number of account",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.AccountNFT": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "token contract address",
+                    "type": "string"
+                },
+                "blockNumber": {
+                    "description": "block number of the last transfer",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "collection name",
+                    "type": "string"
+                },
+                "owner": {
+                    "description": "owner, the zero address when burnt",
+                    "type": "string"
+                },
+                "symbol": {
+                    "description": "collection symbol",
+                    "type": "string"
+                },
+                "tokenId": {
+                    "description": "Token ID",
+                    "type": "string"
+                },
+                "txHash": {
+                    "description": "transaction hash of the last transfer",
+                    "type": "string"
+                }
+            }
+        },
+        "service.AccountNFTsRes": {
+            "type": "object",
+            "properties": {
+                "nfts": {
+                    "description": "token list, the last received first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.AccountNFT"
+                    }
+                },
+                "total": {
+                    "description": "The total number of tokens",
+                    "type": "integer"
+                }
+            }
+        },
         "service.AccountRes": {
             "type": "object",
             "properties": {
@@ -2549,7 +4454,7 @@ const docTemplate = `{
                     "type": "string"
                 },
                 "creator": {
-                    "description": "address",
+                    "description": "the creator, the contract account has value",
                     "type": "string"
                 },
                 "lastNumber": {
@@ -2568,7 +4473,7 @@ const docTemplate = `{
                     "type": "integer"
                 },
                 "number": {
-                    "description": "transaction random number, transaction volume",
+                    "description": "last update block number",
                     "type": "integer"
                 },
                 "profit": {
@@ -2603,9 +4508,17 @@ const docTemplate = `{
                     "description": "symbol",
                     "type": "string"
                 },
+                "timestamp": {
+                    "description": "The event stamp of the account it is in",
+                    "type": "integer"
+                },
                 "type": {
                     "description": "contract types, ERC20, ERC721, ERC1155",
-                    "type": "integer"
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.ContractType"
+                        }
+                    ]
                 },
                 "validatorAmount": {
                     "description": "validator pledge amount",
@@ -2621,6 +4534,71 @@ const docTemplate = `{
                 }
             }
         },
+        "service.AccountToken": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "token contract address",
+                    "type": "string"
+                },
+                "balance": {
+                    "description": "balance, the number of tokens for ERC721",
+                    "type": "string"
+                },
+                "decimals": {
+                    "description": "ERC20 decimals",
+                    "type": "integer"
+                },
+                "holder": {
+                    "description": "holder address",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "name",
+                    "type": "string"
+                },
+                "percentage": {
+                    "description": "share of the total supply in percent, empty for ERC1155 or without the total supply",
+                    "type": "number"
+                },
+                "symbol": {
+                    "description": "symbol",
+                    "type": "string"
+                },
+                "tokenId": {
+                    "description": "ERC1155 token ID, empty for ERC20 and ERC721",
+                    "type": "string"
+                },
+                "totalSupply": {
+                    "description": "total supply",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "contract type, NONE when the contract is not a registered token",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.ContractType"
+                        }
+                    ]
+                }
+            }
+        },
+        "service.AccountTokensRes": {
+            "type": "object",
+            "properties": {
+                "tokens": {
+                    "description": "token balance list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.AccountToken"
+                    }
+                },
+                "total": {
+                    "description": "The total number of token balances",
+                    "type": "integer"
+                }
+            }
+        },
         "service.AccountsRes": {
             "type": "object",
             "properties": {
@@ -2647,7 +4625,7 @@ const docTemplate = `{
                                 "type": "string"
                             },
                             "creator": {
-                                "description": "address",
+                                "description": "the creator, the contract account has value",
                                 "type": "string"
                             },
                             "name": {
@@ -2663,7 +4641,7 @@ const docTemplate = `{
                                 "type": "integer"
                             },
                             "number": {
-                                "description": "transaction random number, transaction volume",
+                                "description": "last update block number",
                                 "type": "integer"
                             },
                             "snftCount": {
@@ -2682,9 +4660,17 @@ const docTemplate = `{
                                 "description": "symbol",
                                 "type": "string"
                             },
+                            "timestamp": {
+                                "description": "The event stamp of the account it is in",
+                                "type": "integer"
+                            },
                             "type": {
                                 "description": "contract types, ERC20, ERC721, ERC1155",
-                                "type": "integer"
+                                "allOf": [
+                                    {
+                                        "$ref": "#/definitions/types.ContractType"
+                                    }
+                                ]
                             },
                             "validatorAmount": {
                                 "description": "validator pledge amount",
@@ -2693,29 +4679,85 @@ const docTemplate = `{
                         }
                     }
                 },
-                "balance": {
-                    "description": "The total amount of coins in the chain",
+                "balance": {
+                    "description": "The total amount of coins in the chain",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "Total number of accounts",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.BlocksRes": {
+            "type": "object",
+            "properties": {
+                "blocks": {
+                    "description": "block list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Block"
+                    }
+                },
+                "next": {
+                    "description": "cursor of the next page, empty on the last page",
                     "type": "string"
                 },
                 "total": {
-                    "description": "Total number of accounts",
+                    "description": "The total number of blocks, not counted on the filtered pages after a cursor",
                     "type": "integer"
                 }
             }
         },
-        "service.BlocksRes": {
+        "service.CallFrame": {
             "type": "object",
             "properties": {
-                "blocks": {
-                    "description": "block list",
+                "calls": {
+                    "description": "calls made by this call",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Block"
+                        "$ref": "#/definitions/service.CallFrame"
                     }
                 },
-                "total": {
-                    "description": "The total number of blocks",
+                "error": {
+                    "description": "exec error",
+                    "type": "string"
+                },
+                "from": {
+                    "description": "Originating address",
+                    "type": "string"
+                },
+                "gas": {
+                    "description": "Gas",
                     "type": "integer"
+                },
+                "gasUsed": {
+                    "description": "Gas consumption",
+                    "type": "integer"
+                },
+                "index": {
+                    "description": "call index, -1: the transaction",
+                    "type": "integer"
+                },
+                "input": {
+                    "description": "call data",
+                    "type": "string"
+                },
+                "op": {
+                    "description": "Operation",
+                    "type": "string"
+                },
+                "output": {
+                    "description": "return data",
+                    "type": "string"
+                },
+                "to": {
+                    "description": "Receive address, the created contract address of a creation",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Amount, unit wei",
+                    "type": "string"
                 }
             }
         },
@@ -2735,6 +4777,106 @@ const docTemplate = `{
                 }
             }
         },
+        "service.ERC1155TransfersRes": {
+            "type": "object",
+            "properties": {
+                "next": {
+                    "description": "cursor of the next page, empty on the last page",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "The total number of transfers, not counted on the pages after a cursor",
+                    "type": "integer"
+                },
+                "transfers": {
+                    "description": "transfer list, the latest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ERC1155Transfer"
+                    }
+                }
+            }
+        },
+        "service.ERC20TransfersRes": {
+            "type": "object",
+            "properties": {
+                "next": {
+                    "description": "cursor of the next page, empty on the last page",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "The total number of transfers, not counted on the pages after a cursor",
+                    "type": "integer"
+                },
+                "transfers": {
+                    "description": "transfer list, the latest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ERC20Transfer"
+                    }
+                }
+            }
+        },
+        "service.ERC721TokenRes": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "token contract address",
+                    "type": "string"
+                },
+                "blockNumber": {
+                    "description": "block number of the last transfer",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "collection name",
+                    "type": "string"
+                },
+                "owner": {
+                    "description": "owner, the zero address when burnt",
+                    "type": "string"
+                },
+                "symbol": {
+                    "description": "collection symbol",
+                    "type": "string"
+                },
+                "tokenId": {
+                    "description": "Token ID",
+                    "type": "string"
+                },
+                "transfers": {
+                    "description": "all the transfers of the token, the mint first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ERC721Transfer"
+                    }
+                },
+                "txHash": {
+                    "description": "transaction hash of the last transfer",
+                    "type": "string"
+                }
+            }
+        },
+        "service.ERC721TransfersRes": {
+            "type": "object",
+            "properties": {
+                "next": {
+                    "description": "cursor of the next page, empty on the last page",
+                    "type": "string"
+                },
+                "total": {
+                    "description": "The total number of transfers, not counted on the pages after a cursor",
+                    "type": "integer"
+                },
+                "transfers": {
+                    "description": "transfer list, the latest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ERC721Transfer"
+                    }
+                }
+            }
+        },
         "service.EpochsRes": {
             "type": "object",
             "properties": {
@@ -2761,8 +4903,12 @@ const docTemplate = `{
                         "$ref": "#/definitions/model.Erbie"
                     }
                 },
+                "next": {
+                    "description": "cursor of the next page, empty on the last page",
+                    "type": "string"
+                },
                 "total": {
-                    "description": "The total number of transactions",
+                    "description": "The total number of transactions, not counted on the pages after a cursor",
                     "type": "integer"
                 }
             }
@@ -2776,6 +4922,61 @@ const docTemplate = `{
                 }
             }
         },
+        "service.EventLogRes": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "The contract address",
+                    "type": "string"
+                },
+                "blockNumber": {
+                    "description": "block number",
+                    "type": "integer"
+                },
+                "data": {
+                    "description": "data",
+                    "type": "string"
+                },
+                "decoded": {
+                    "description": "event and parameters, with the ABI of the emitting contract",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.ABIDecoded"
+                        }
+                    ]
+                },
+                "event": {
+                    "description": "text signature of the event, best effort from the known topics",
+                    "type": "string"
+                },
+                "eventCandidates": {
+                    "description": "all the known text signatures when the topic is ambiguous",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "logIndex": {
+                    "description": "The serial number in the transaction",
+                    "type": "integer"
+                },
+                "removed": {
+                    "description": "whether to remove",
+                    "type": "boolean"
+                },
+                "topics": {
+                    "description": "topic",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "transactionHash": {
+                    "description": "The transaction hash",
+                    "type": "string"
+                }
+            }
+        },
         "service.InternalTxsRes": {
             "type": "object",
             "properties": {
@@ -2786,12 +4987,32 @@ const docTemplate = `{
                         "$ref": "#/definitions/model.InternalTx"
                     }
                 },
+                "next": {
+                    "description": "cursor of the next page, empty on the last page",
+                    "type": "string"
+                },
                 "total": {
                     "description": "The total number",
                     "type": "integer"
                 }
             }
         },
+        "service.InventoryRes": {
+            "type": "object",
+            "properties": {
+                "tokens": {
+                    "description": "token list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ERC721Token"
+                    }
+                },
+                "total": {
+                    "description": "The total number of tokens",
+                    "type": "integer"
+                }
+            }
+        },
         "service.LineChartRes": {
             "type": "object",
             "properties": {
@@ -2832,7 +5053,7 @@ const docTemplate = `{
                         "type": "object",
                         "properties": {
                             "gasPrice": {
-                                "type": "integer"
+                                "type": "string"
                             },
                             "hash": {
                                 "type": "string"
@@ -2849,6 +5070,14 @@ const docTemplate = `{
                     "description": "account address",
                     "type": "string"
                 },
+                "city": {
+                    "description": "city",
+                    "type": "string"
+                },
+                "country": {
+                    "description": "country",
+                    "type": "string"
+                },
                 "latitude": {
                     "description": "latitude",
                     "type": "number"
@@ -2903,6 +5132,26 @@ const docTemplate = `{
                 }
             }
         },
+        "service.OrphanedTxRes": {
+            "type": "object",
+            "properties": {
+                "included": {
+                    "description": "whether the transaction is in a block of the current chain",
+                    "type": "boolean"
+                },
+                "orphaned": {
+                    "description": "whether the transaction was ever in an orphaned block",
+                    "type": "boolean"
+                },
+                "records": {
+                    "description": "the transaction in the orphaned blocks, by reorg",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.OrphanedTx"
+                    }
+                }
+            }
+        },
         "service.PledgesRes": {
             "type": "object",
             "properties": {
@@ -2929,40 +5178,160 @@ const docTemplate = `{
                         "$ref": "#/definitions/model.NFT"
                     }
                 },
-                "total": {
-                    "description": "The total number of NFTs",
+                "total": {
+                    "description": "The total number of NFTs",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.RankingSNFTRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "SNFT list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.SNFT"
+                    }
+                },
+                "total": {
+                    "description": "The total number of SNFTs",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.RankingStakerRes": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Staker list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Staker"
+                    }
+                },
+                "total": {
+                    "description": "The total number of Staker",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.ReadContractReq": {
+            "type": "object",
+            "properties": {
+                "abi": {
+                    "description": "contract ABI, default the uploaded ABI of the contract",
+                    "type": "array",
+                    "items": {
+                        "type": "object"
+                    }
+                },
+                "args": {
+                    "description": "arguments: numbers as JSON numbers or decimal or hex strings, addresses and bytes as hex strings, arrays as lists, tuples as lists or objects",
+                    "type": "array",
+                    "items": {}
+                },
+                "block": {
+                    "description": "block number, default latest",
+                    "type": "string"
+                },
+                "function": {
+                    "description": "function name, or text signature for the overloaded functions, e.g. balanceOf(address)",
+                    "type": "string"
+                }
+            }
+        },
+        "service.ReadContractRes": {
+            "type": "object",
+            "properties": {
+                "block": {
+                    "description": "block number the function is called at",
+                    "type": "string"
+                },
+                "outputs": {
+                    "description": "decoded return values, empty when the function outputs are not known",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.ABIValue"
+                    }
+                },
+                "raw": {
+                    "description": "raw return data",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "signature of the called function",
+                    "type": "string"
+                }
+            }
+        },
+        "service.ReorgRes": {
+            "type": "object",
+            "properties": {
+                "blocks": {
+                    "description": "orphaned block hashes, in height order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "depth": {
+                    "description": "number of orphaned blocks",
+                    "type": "integer"
+                },
+                "forkNumber": {
+                    "description": "number of the last block common to both chains",
+                    "type": "integer"
+                },
+                "id": {
+                    "description": "reorg id",
                     "type": "integer"
-                }
-            }
-        },
-        "service.RankingSNFTRes": {
-            "type": "object",
-            "properties": {
-                "data": {
-                    "description": "SNFT list",
+                },
+                "newHash": {
+                    "description": "hash of the block of the new chain that revealed the reorg",
+                    "type": "string"
+                },
+                "newHead": {
+                    "description": "number of the block of the new chain that revealed the reorg",
+                    "type": "integer"
+                },
+                "oldHash": {
+                    "description": "hash of the stored head before the reorg",
+                    "type": "string"
+                },
+                "oldHead": {
+                    "description": "number of the stored head before the reorg",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "time the reorg was found",
+                    "type": "integer"
+                },
+                "transactions": {
+                    "description": "orphaned transactions",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.SNFT"
+                        "$ref": "#/definitions/model.OrphanedTx"
                     }
                 },
-                "total": {
-                    "description": "The total number of SNFTs",
+                "txCount": {
+                    "description": "number of orphaned transactions",
                     "type": "integer"
                 }
             }
         },
-        "service.RankingStakerRes": {
+        "service.ReorgsRes": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "Staker list",
+                "reorgs": {
+                    "description": "reorg list",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.Staker"
+                        "$ref": "#/definitions/model.Reorg"
                     }
                 },
                 "total": {
-                    "description": "The total number of Staker",
+                    "description": "The total number of reorgs",
                     "type": "integer"
                 }
             }
@@ -2970,6 +5339,10 @@ const docTemplate = `{
         "service.RewardsRes": {
             "type": "object",
             "properties": {
+                "next": {
+                    "description": "cursor of the next page, empty on the last page",
+                    "type": "string"
+                },
                 "rewards": {
                     "description": "Rewards list",
                     "type": "array",
@@ -3068,6 +5441,64 @@ const docTemplate = `{
                 }
             }
         },
+        "service.SearchRes": {
+            "type": "object",
+            "properties": {
+                "query": {
+                    "description": "the query as classified",
+                    "type": "string"
+                },
+                "results": {
+                    "description": "matching objects, empty when nothing matches",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.SearchResult"
+                    }
+                }
+            }
+        },
+        "service.SearchResult": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "description": "block number, transaction hash, address or epoch ID, the parameter of the query route of the type",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "contract name",
+                    "type": "string"
+                },
+                "symbol": {
+                    "description": "contract symbol",
+                    "type": "string"
+                },
+                "token": {
+                    "description": "contract type, ERC20, ERC721 or ERC1155",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/types.ContractType"
+                        }
+                    ]
+                },
+                "type": {
+                    "description": "block, transaction, account, contract, token, nft, snft or epoch",
+                    "type": "string"
+                }
+            }
+        },
+        "service.SignatureReq": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "description": "function or event",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "text signature, e.g. transfer(address,uint256)",
+                    "type": "string"
+                }
+            }
+        },
         "service.SlashingsRes": {
             "type": "object",
             "properties": {
@@ -3100,11 +5531,269 @@ const docTemplate = `{
                 }
             }
         },
+        "service.TokenHolder": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "description": "token contract address",
+                    "type": "string"
+                },
+                "balance": {
+                    "description": "balance, the number of tokens for ERC721",
+                    "type": "string"
+                },
+                "holder": {
+                    "description": "holder address",
+                    "type": "string"
+                },
+                "percentage": {
+                    "description": "share of the total supply in percent, empty for ERC1155 or without the total supply",
+                    "type": "number"
+                },
+                "tokenId": {
+                    "description": "ERC1155 token ID, empty for ERC20 and ERC721",
+                    "type": "string"
+                }
+            }
+        },
+        "service.TokenHoldersRes": {
+            "type": "object",
+            "properties": {
+                "holders": {
+                    "description": "holder list, by balance",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/service.TokenHolder"
+                    }
+                },
+                "total": {
+                    "description": "The total number of holders",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.TokenTransfers": {
+            "type": "object",
+            "properties": {
+                "erc1155": {
+                    "description": "ERC1155 transfers",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ERC1155Transfer"
+                    }
+                },
+                "erc20": {
+                    "description": "ERC20 transfers",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ERC20Transfer"
+                    }
+                },
+                "erc721": {
+                    "description": "ERC721 transfers",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.ERC721Transfer"
+                    }
+                }
+            }
+        },
+        "service.TokensRes": {
+            "type": "object",
+            "properties": {
+                "tokens": {
+                    "description": "token list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Token"
+                    }
+                },
+                "total": {
+                    "description": "The total number of tokens",
+                    "type": "integer"
+                }
+            }
+        },
+        "service.TransactionRes": {
+            "type": "object",
+            "properties": {
+                "accessList": {
+                    "description": "EIP-2930 access list",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AccessTuple"
+                    }
+                },
+                "blockHash": {
+                    "description": "Block Hash",
+                    "type": "string"
+                },
+                "blockNumber": {
+                    "description": "block number",
+                    "type": "integer"
+                },
+                "chainId": {
+                    "description": "chain id, empty for legacy transactions before EIP-155",
+                    "type": "integer"
+                },
+                "confirmations": {
+                    "description": "number of blocks from the block of the transaction to the chain head, both included",
+                    "type": "integer"
+                },
+                "contractAddress": {
+                    "description": "The created contract address",
+                    "type": "string"
+                },
+                "cumulativeGasUsed": {
+                    "description": "Cumulative gas consumption",
+                    "type": "integer"
+                },
+                "decodedError": {
+                    "description": "custom error and arguments of the revert data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.ABIDecoded"
+                        }
+                    ]
+                },
+                "decodedInput": {
+                    "description": "called function and arguments, with the ABI of the called contract",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/utils.ABIDecoded"
+                        }
+                    ]
+                },
+                "effectiveGasPrice": {
+                    "description": "gas price actually paid, from the receipt",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "exec error",
+                    "type": "string"
+                },
+                "finalized": {
+                    "description": "whether the block of the transaction has the confirmations required to be final",
+                    "type": "boolean"
+                },
+                "from": {
+                    "description": "Send address",
+                    "type": "string"
+                },
+                "gas": {
+                    "description": "fuel",
+                    "type": "integer"
+                },
+                "gasPrice": {
+                    "description": "Gas price, the fee cap for EIP-1559 transactions",
+                    "type": "string"
+                },
+                "gasUsed": {
+                    "description": "Gas consumption",
+                    "type": "integer"
+                },
+                "hash": {
+                    "description": "Hash",
+                    "type": "string"
+                },
+                "input": {
+                    "description": "Additional input data, contract call encoded data",
+                    "type": "string"
+                },
+                "maxFeePerGas": {
+                    "description": "EIP-1559 fee cap, unit wei",
+                    "type": "string"
+                },
+                "maxPriorityFeePerGas": {
+                    "description": "EIP-1559 tip cap, unit wei",
+                    "type": "string"
+                },
+                "method": {
+                    "description": "text signature of the called function, best effort from the known selectors",
+                    "type": "string"
+                },
+                "methodCandidates": {
+                    "description": "all the known text signatures when the selector is ambiguous",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "nonce": {
+                    "description": "Random number, the number of transactions initiated by the account",
+                    "type": "integer"
+                },
+                "panicCode": {
+                    "description": "Panic(uint256) code",
+                    "type": "integer"
+                },
+                "r": {
+                    "description": "signature R",
+                    "type": "string"
+                },
+                "revertData": {
+                    "description": "return data of the failed transaction",
+                    "type": "string"
+                },
+                "revertReason": {
+                    "description": "decoded revert reason, Error(string), panic description or custom error",
+                    "type": "string"
+                },
+                "s": {
+                    "description": "signature S",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status, 1: success; 0: failure",
+                    "type": "integer"
+                },
+                "timestamp": {
+                    "description": "The event stamp of the block it is in",
+                    "type": "integer"
+                },
+                "to": {
+                    "description": "Receive address",
+                    "type": "string"
+                },
+                "tokenTransfers": {
+                    "description": "token transfers of the transaction",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/service.TokenTransfers"
+                        }
+                    ]
+                },
+                "transactionIndex": {
+                    "description": "The serial number in the block",
+                    "type": "integer"
+                },
+                "txFee": {
+                    "description": "gas used times the effective gas price, unit wei",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "transaction type, 0: legacy; 1: EIP-2930; 2: EIP-1559",
+                    "type": "integer"
+                },
+                "v": {
+                    "description": "signature V, y parity for typed transactions",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "Amount, unit wei",
+                    "type": "string"
+                }
+            }
+        },
         "service.TransactionsRes": {
             "type": "object",
             "properties": {
+                "next": {
+                    "description": "cursor of the next page, empty on the last page",
+                    "type": "string"
+                },
                 "total": {
-                    "description": "The total number of transactions",
+                    "description": "The total number of transactions, not counted on the filtered pages after a cursor",
                     "type": "integer"
                 },
                 "transactions": {
@@ -3128,6 +5817,57 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "types.ContractType": {
+            "type": "integer",
+            "enum": [
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-varnames": [
+                "ERC20",
+                "ERC165",
+                "ERC721",
+                "ERC1155"
+            ]
+        },
+        "utils.ABIDecoded": {
+            "type": "object",
+            "properties": {
+                "args": {
+                    "description": "decoded arguments",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/utils.ABIValue"
+                    }
+                },
+                "name": {
+                    "description": "function, event or error name",
+                    "type": "string"
+                },
+                "signature": {
+                    "description": "canonical signature, e.g. transfer(address,uint256)",
+                    "type": "string"
+                }
+            }
+        },
+        "utils.ABIValue": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "argument name, may be empty",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "solidity type",
+                    "type": "string"
+                },
+                "value": {
+                    "description": "numbers as decimal strings, bytes as hex, arrays as lists, tuples as lists of ABIValue"
+                }
+            }
         }
     }
 }`
@@ -3142,6 +5882,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "Block browser back-end interface, parses data from the blockchain, provides information retrieval services for blocks, transactions, NFT, SNFT, validators, and rewards",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
@@ -1,21 +1,235 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
 
 	"server/backend"
+	"server/common/model"
+	"server/common/types"
 	"server/conf"
 	"server/router"
+	"server/service"
 )
 
+const usage = `Usage: server [command] [flags]
+
+Without a command the API and the indexer run together.
+
+Commands:
+  serve                       run the query API only
+  index                       run the indexer only
+  reindex --from N --to M     decode and write the stored blocks N..M again
+  reset --yes                 clear the indexed data
+  verify [--count N]          compare N random stored blocks with the chain node
+  sethead [--verify] N        roll the stored chain back to the block N, --verify first checks the
+                              stored block N against the chain node
+  apikey issue --name NAME --role public|partner|admin
+                              issue an API key, it is printed only once
+  apikey revoke ID            revoke the API key
+  apikey list                 list the API keys and their usage
+`
+
 // @title       block explorer API
 // @version     1.0
 // @description Block browser back-end interface, parses data from the blockchain, provides information retrieval services for blocks, transactions, NFT, SNFT, validators, and rewards
 func main() {
-	if err := backend.Run(conf.ChainUrl, conf.Thread, conf.Interval); err != nil {
-		log.Printf("Backend failed to run： %v\n", err)
+	// SIGINT and SIGTERM cancel the context, every part finishes its current work and stops
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	command, args := "", os.Args[1:]
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+	err := backend.SetProfile(conf.ChainProfile)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if err = service.Open(conf.DatabaseDsn); err != nil {
+		log.Fatalln(err)
+	}
+	switch command {
+	case "":
+		err = run(ctx, stop, serve, func(ctx context.Context) error {
+			// the API keeps serving the stored data when the indexer fails
+			_ = index(ctx)
+			return nil
+		})
+	case "serve":
+		err = run(ctx, stop, serve, func(ctx context.Context) error {
+			// the stats are only advanced by the indexer, which runs in another process
+			service.WatchStats(ctx, 10*time.Second)
+			return nil
+		})
+	case "index":
+		err = run(ctx, stop, index)
+	case "reindex":
+		flags := flag.NewFlagSet(command, flag.ExitOnError)
+		from := flags.Int64("from", 0, "first block to reindex")
+		to := flags.Int64("to", 0, "last block to reindex")
+		_ = flags.Parse(args)
+		err = backend.Reindex(ctx, conf.ChainUrl, conf.BatchSize, types.Long(*from), types.Long(*to))
+	case "reset":
+		flags := flag.NewFlagSet(command, flag.ExitOnError)
+		yes := flags.Bool("yes", false, "confirm dropping all the indexed data")
+		_ = flags.Parse(args)
+		if !*yes {
+			err = fmt.Errorf("reset drops all the indexed data, run it with --yes to confirm")
+		} else {
+			err = model.ClearTable(service.DB)
+		}
+	case "verify":
+		flags := flag.NewFlagSet(command, flag.ExitOnError)
+		count := flags.Int("count", 100, "number of random blocks to compare")
+		_ = flags.Parse(args)
+		var mismatches []types.Long
+		if mismatches, err = backend.Verify(ctx, conf.ChainUrl, *count); err == nil && len(mismatches) > 0 {
+			err = fmt.Errorf("%v blocks do not match the chain node: %v", len(mismatches), mismatches)
+		}
+	case "sethead":
+		// the flag is picked by hand, a negative block number would be taken for a flag
+		verify, rest := false, make([]string, 0, len(args))
+		for _, arg := range args {
+			if arg == "--verify" || arg == "-verify" {
+				verify = true
+			} else {
+				rest = append(rest, arg)
+			}
+		}
+		var number int64
+		if len(rest) != 1 {
+			err = fmt.Errorf("sethead takes one block number")
+		} else if number, err = strconv.ParseInt(rest[0], 0, 64); err == nil && verify {
+			err = backend.SetHead(ctx, conf.ChainUrl, types.Long(number))
+		} else if err == nil {
+			parsed := &model.Parsed{Block: &model.Block{}}
+			parsed.Number = types.Long(number)
+			err = service.SetHead(parsed)
+		}
+	case "apikey":
+		err = apiKey(args)
+	default:
+		fmt.Print(usage)
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Printf("Command %q failed: %v\n", command, err)
+		os.Exit(1)
+	}
+}
+
+// run starts the services and waits until all of them stop, a service that fails stops the others
+func run(ctx context.Context, stop context.CancelFunc, services ...func(context.Context) error) (err error) {
+	var wg sync.WaitGroup
+	var once sync.Once
+	for _, service := range services {
+		wg.Add(1)
+		go func(service func(context.Context) error) {
+			defer wg.Done()
+			if e := service(ctx); e != nil {
+				once.Do(func() { err = e })
+				stop()
+			}
+		}(service)
 	}
-	if err := router.Run(conf.ServerAddr); err != nil {
+	wg.Wait()
+	log.Println("Shutdown complete")
+	return
+}
+
+// serve runs the query API, the validator log watcher, the API key usage writer and the reindex jobs
+func serve(ctx context.Context) error {
+	// the watchers also stop when the server fails to run
+	ctx, cancel := context.WithCancel(ctx)
+	go func() {
+		if err := service.WatchValidator(ctx); err != nil {
+			log.Printf("Validator watcher failed to run： %v\n", err)
+		}
+	}()
+	usage := make(chan struct{})
+	go func() {
+		service.WatchKeyUsage(ctx, 10*time.Second)
+		close(usage)
+	}()
+	reindex := make(chan struct{})
+	go func() {
+		backend.RunReindexJobs(ctx)
+		close(reindex)
+	}()
+	err := router.Run(ctx, conf.ServerAddr, conf.ShutdownTimeout)
+	if err != nil {
 		log.Printf("Server failed to run： %v\n", err)
 	}
+	cancel()
+	// the usage of the last requests is written once the server has stopped
+	<-usage
+	<-reindex
+	return err
+}
+
+// index runs the indexer
+func index(ctx context.Context) error {
+	// without the unconfirmed blocks the indexer stays behind the chain head by the confirmation depth
+	lag := int64(0)
+	if !conf.IndexUnconfirmed {
+		lag = conf.Confirmations
+	}
+	err := backend.Run(ctx, conf.ChainUrl, conf.Thread, lag, conf.Interval, conf.BatchSize)
+	if err != nil {
+		log.Printf("Backend failed to run： %v\n", err)
+	}
+	return err
+}
+
+// apiKey issues, revokes and lists the API keys
+func apiKey(args []string) (err error) {
+	command := ""
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
+	}
+	switch command {
+	case "issue":
+		flags := flag.NewFlagSet("apikey issue", flag.ExitOnError)
+		name := flags.String("name", "", "name of the client")
+		role := flags.String("role", model.RolePartner, "role of the key: public, partner or admin")
+		_ = flags.Parse(args)
+		var key string
+		var res model.APIKey
+		if key, res, err = service.IssueAPIKey(*name, *role); err == nil {
+			fmt.Printf("Issued %v key %v for %q, send it in the X-API-Key header:\n%v\n", res.Role, res.ID, res.Name, key)
+		}
+	case "revoke":
+		var id int64
+		if len(args) != 1 {
+			err = fmt.Errorf("apikey revoke takes one key id")
+		} else if id, err = strconv.ParseInt(args[0], 10, 64); err == nil {
+			err = service.RevokeAPIKey(id)
+		}
+	case "list":
+		var keys []model.APIKey
+		if keys, err = service.FetchAPIKeys(); err == nil {
+			fmt.Printf("%-6v %-8v %-8v %-10v %-12v %-12v %v\n", "ID", "PREFIX", "ROLE", "REQUESTS", "LAST USED", "REVOKED", "NAME")
+			for _, key := range keys {
+				lastUsed, revoked := "-", "-"
+				if key.LastUsed != nil {
+					lastUsed = time.Unix(int64(*key.LastUsed), 0).Format("2006-01-02")
+				}
+				if key.RevokedAt != nil {
+					revoked = time.Unix(int64(*key.RevokedAt), 0).Format("2006-01-02")
+				}
+				fmt.Printf("%-6v %-8v %-8v %-10v %-12v %-12v %v\n", key.ID, key.Prefix, key.Role, key.Requests, lastUsed, revoked, key.Name)
+			}
+		}
+	default:
+		err = fmt.Errorf("unknown apikey command %q, issue, revoke or list", command)
+	}
+	return
 }
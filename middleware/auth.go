@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"server/common/model"
+	"server/conf"
+	"server/service"
+)
+
+// keyLookups limits the keys each IP may have looked up in the database, the valid keys are cached so that only
+// the unknown keys, e.g. guessed ones, run out of it
+var keyLookups = conf.RateLimit{Rate: 1, Burst: 10}
+
+// Auth resolves the API key of the X-API-Key header to the role of the request and counts its usage, the requests
+// without a key are public. The configured admin key has the admin role without being stored
+func Auth(adminKey string) gin.HandlerFunc {
+	lookups := &limiter{limits: map[string]conf.RateLimit{"lookup": keyLookups}, buckets: make(map[string]*bucket)}
+	return func(context *gin.Context) {
+		given := context.GetHeader("X-API-Key")
+		switch {
+		case given == "":
+			context.Set("role", model.RolePublic)
+		case adminKey != "" && subtle.ConstantTimeCompare([]byte(given), []byte(adminKey)) == 1:
+			context.Set("role", model.RoleAdmin)
+		default:
+			key := service.CachedAPIKey(given)
+			if key == nil {
+				if allowed, _, _, wait := lookups.take("lookup ip:"+context.ClientIP(), keyLookups, time.Now()); !allowed {
+					context.Header("Retry-After", strconv.FormatInt(seconds(wait), 10))
+					context.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"err_str": "too many API key lookups, retry later"})
+					return
+				}
+			}
+			key, err := service.Authenticate(given)
+			if errors.Is(err, service.ErrInvalidKey) {
+				context.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"err_str": err.Error()})
+				return
+			} else if err != nil {
+				context.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"err_str": err.Error()})
+				return
+			}
+			context.Set("role", key.Role)
+			context.Set("apiKey", key)
+			service.RecordKeyUsage(key.ID)
+		}
+		context.Next()
+	}
+}
+
+// Require only lets through the requests whose role is the role or a higher one
+func Require(role string) gin.HandlerFunc {
+	return func(context *gin.Context) {
+		if given := context.GetString("role"); rank(given) < rank(role) {
+			status := http.StatusForbidden
+			if given == model.RolePublic {
+				status = http.StatusUnauthorized
+			}
+			context.AbortWithStatusJSON(status, gin.H{"err_str": "the " + role + " role is required, not allow"})
+			return
+		}
+		context.Next()
+	}
+}
+
+// APIKey returns the API key of the request, nil for the public requests and the configured admin key
+func APIKey(context *gin.Context) *model.APIKey {
+	if key, ok := context.Get("apiKey"); ok {
+		return key.(*model.APIKey)
+	}
+	return nil
+}
+
+func rank(role string) int {
+	for i, r := range model.Roles {
+		if r == role {
+			return i
+		}
+	}
+	return -1
+}
@@ -7,9 +7,9 @@ import (
 func Cors() gin.HandlerFunc {
 	return func(context *gin.Context) {
 		context.Header("Access-Control-Allow-Origin", "*") // You can replace * with the specified domain name
-		context.Header("Access-Control-Allow-Headers", "Content-Type,AccessToken,X-CSRF-Token, Authorization, Token")
+		context.Header("Access-Control-Allow-Headers", "Content-Type,AccessToken,X-CSRF-Token, Authorization, Token, X-API-Key")
 		context.Header("Access-Control-Allow-Methods", "GET,POST,PUT,DELETE,PATCH,OPTIONS")
-		context.Header("Access-Control-Expose-Headers", "Accept, Authorization, Version, Token,Content-Length, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Content-Type")
+		context.Header("Access-Control-Expose-Headers", "Accept, Authorization, Version, Token,Content-Length, Access-Control-Allow-Origin, Access-Control-Allow-Headers, Content-Type, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, Retry-After, X-Quota-Limit, X-Quota-Remaining")
 		context.Header("Access-Control-Allow-Credentials", "true")
 		if context.Request.Method == "OPTIONS" {
 			context.Status(200)
@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"server/common/model"
+	"server/conf"
+	"server/service"
+)
+
+// bucket token bucket of a client in a route group
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+type limiter struct {
+	sync.Mutex
+	limits   map[string]conf.RateLimit
+	prefixes []string           //route group prefixes, the longest first
+	quotas   map[string]int64   //daily quota of each role
+	buckets  map[string]*bucket //buckets by route group and client
+	swept    time.Time          //last time the full buckets were dropped
+	day      string             //day of the requests counted in used
+	used     map[string]int64   //requests of the clients in the day
+}
+
+// RateLimit limits the requests of each client, identified by its API key or else by its IP, with a token bucket per
+// route group and a daily quota per role. The admin role is not limited. The client IP is taken from the
+// X-Forwarded-For header only for the requests coming from the trusted proxies of the engine
+func RateLimit(limits map[string]conf.RateLimit, quotas map[string]int64) gin.HandlerFunc {
+	l := &limiter{limits: limits, quotas: quotas, buckets: make(map[string]*bucket), used: make(map[string]int64)}
+	for prefix := range limits {
+		if prefix != "*" {
+			l.prefixes = append(l.prefixes, prefix)
+		}
+	}
+	sort.Slice(l.prefixes, func(i, j int) bool { return len(l.prefixes[i]) > len(l.prefixes[j]) })
+	return func(context *gin.Context) {
+		role := context.GetString("role")
+		if role == model.RoleAdmin {
+			context.Next()
+			return
+		}
+		client, key := "ip:"+context.ClientIP(), APIKey(context)
+		if key != nil {
+			client = "key:" + strconv.FormatInt(key.ID, 10)
+		}
+		now := time.Now()
+		if group, limit, ok := l.group(context.Request.URL.Path); ok {
+			allowed, remaining, reset, wait := l.take(group+" "+client, limit, now)
+			context.Header("X-RateLimit-Limit", strconv.Itoa(limit.Burst))
+			context.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+			context.Header("X-RateLimit-Reset", strconv.FormatInt(seconds(reset), 10))
+			if !allowed {
+				context.Header("Retry-After", strconv.FormatInt(seconds(wait), 10))
+				context.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"err_str": "rate limit exceeded, retry later"})
+				return
+			}
+		}
+		if quota := l.quotas[role]; quota > 0 {
+			used := l.count(client, key, now)
+			remaining := quota - used
+			if remaining < 0 {
+				remaining = 0
+			}
+			context.Header("X-Quota-Limit", strconv.FormatInt(quota, 10))
+			context.Header("X-Quota-Remaining", strconv.FormatInt(remaining, 10))
+			if used > quota {
+				tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+				context.Header("Retry-After", strconv.FormatInt(seconds(tomorrow.Sub(now)), 10))
+				context.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"err_str": "daily quota exceeded, retry tomorrow"})
+				return
+			}
+		}
+		context.Next()
+	}
+}
+
+// group returns the route group of the path, the longest matching prefix or else *
+func (l *limiter) group(path string) (string, conf.RateLimit, bool) {
+	for _, prefix := range l.prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return prefix, l.limits[prefix], true
+		}
+	}
+	limit, ok := l.limits["*"]
+	return "*", limit, ok
+}
+
+// take takes a token from the bucket, it returns the tokens left, the time until the bucket is full again and,
+// when there was no token, the time until the next one
+func (l *limiter) take(name string, limit conf.RateLimit, now time.Time) (allowed bool, remaining int, reset, wait time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+	l.sweep(now)
+	b := l.buckets[name]
+	if b == nil {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[name] = b
+	}
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+now.Sub(b.last).Seconds()*limit.Rate)
+	b.last = now
+	if allowed = b.tokens >= 1; allowed {
+		b.tokens--
+	} else {
+		wait = time.Duration((1 - b.tokens) / limit.Rate * float64(time.Second))
+	}
+	reset = time.Duration((float64(limit.Burst) - b.tokens) / limit.Rate * float64(time.Second))
+	return allowed, int(b.tokens), reset, wait
+}
+
+// sweep drops the buckets that have been full for a minute, a new bucket starts full anyway
+func (l *limiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < time.Minute {
+		return
+	}
+	l.swept = now
+	for name, b := range l.buckets {
+		if now.Sub(b.last) > time.Minute {
+			limit := l.limits[name[:strings.LastIndexByte(name, ' ')]]
+			if b.tokens+now.Sub(b.last).Seconds()*limit.Rate >= float64(limit.Burst) {
+				delete(l.buckets, name)
+			}
+		}
+	}
+}
+
+// count counts the request of the client in the day and returns the requests of the day, the count of an API key
+// starts from its written usage so that it survives a restart
+func (l *limiter) count(client string, key *model.APIKey, now time.Time) int64 {
+	day := now.Format("2006-01-02")
+	l.Lock()
+	_, seen := l.used[client]
+	seen = seen && l.day == day
+	l.Unlock()
+	var written int64
+	if !seen && key != nil {
+		// a failed lookup only loses the requests made before the restart
+		written, _ = service.KeyUsage(key.ID, day)
+	}
+	l.Lock()
+	defer l.Unlock()
+	if l.day != day {
+		l.day, l.used = day, make(map[string]int64)
+	}
+	if _, ok := l.used[client]; !ok {
+		l.used[client] = written
+	}
+	l.used[client]++
+	return l.used[client]
+}
+
+// seconds rounds the duration up to whole seconds
+func seconds(d time.Duration) int64 {
+	return int64(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"server/common/model"
+	"server/conf"
+)
+
+func TestTake(t *testing.T) {
+	l := &limiter{buckets: make(map[string]*bucket)}
+	limit, now := conf.RateLimit{Rate: 2, Burst: 3}, time.Now()
+	// the burst is allowed at once, then one request every 1/rate
+	for i := 2; i >= 0; i-- {
+		if allowed, remaining, _, _ := l.take("* ip:1", limit, now); !allowed || remaining != i {
+			t.Fatalf("request %v: allowed %v, %v remaining", 3-i, allowed, remaining)
+		}
+	}
+	allowed, _, reset, wait := l.take("* ip:1", limit, now)
+	if allowed || wait != 500*time.Millisecond || reset != 1500*time.Millisecond {
+		t.Fatalf("over the burst: allowed %v, wait %v, reset %v", allowed, wait, reset)
+	}
+	if allowed, _, _, _ := l.take("* ip:2", limit, now); !allowed {
+		t.Fatal("the clients share a bucket")
+	}
+	if allowed, remaining, _, _ := l.take("* ip:1", limit, now.Add(500*time.Millisecond)); !allowed || remaining != 0 {
+		t.Fatalf("after the wait: allowed %v, %v remaining", allowed, remaining)
+	}
+	// the refill stops at the burst
+	if _, remaining, _, _ := l.take("* ip:1", limit, now.Add(time.Hour)); remaining != 2 {
+		t.Fatalf("after an hour: %v remaining", remaining)
+	}
+}
+
+func TestSweep(t *testing.T) {
+	now := time.Now()
+	l := &limiter{limits: map[string]conf.RateLimit{"/api/": {Rate: 1, Burst: 100}}, swept: now, buckets: map[string]*bucket{
+		"/api/ ip:1": {tokens: 99, last: now},                       // not full
+		"/api/ ip:2": {tokens: 0, last: now.Add(-2 * time.Minute)},  // full again after 100 seconds
+		"/api/ ip:3": {tokens: 0, last: now.Add(-30 * time.Second)}, // idle for 90 seconds
+	}}
+	l.sweep(now.Add(30 * time.Second))
+	if len(l.buckets) != 3 {
+		t.Fatal("swept within a minute", len(l.buckets))
+	}
+	l.sweep(now.Add(time.Minute))
+	if _, ok := l.buckets["/api/ ip:2"]; ok || len(l.buckets) != 2 {
+		t.Fatal("buckets after the sweep", l.buckets)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	limits := map[string]conf.RateLimit{"*": {Rate: 1, Burst: 1}, "/api": {Rate: 2, Burst: 2}, "/api/exec": {Rate: 3, Burst: 3}}
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Set("role", c.GetHeader("Role"))
+	}, RateLimit(limits, map[string]int64{model.RolePublic: 4}))
+	engine.NoRoute(func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	request := func(path, role string) *httptest.ResponseRecorder {
+		w, r := httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil)
+		r.Header.Set("Role", role)
+		engine.ServeHTTP(w, r)
+		return w
+	}
+	// the longest prefix gives the limit, the groups have their own buckets
+	for path, burst := range map[string]string{"/api/exec/sql": "3", "/api/block": "2", "/home": "1"} {
+		if w := request(path, model.RolePublic); w.Code != http.StatusOK || w.Header().Get("X-RateLimit-Limit") != burst {
+			t.Fatalf("%v: status %v, limit %v", path, w.Code, w.Header().Get("X-RateLimit-Limit"))
+		}
+	}
+	if w := request("/home", model.RolePublic); w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") != "1" {
+		t.Fatalf("over the limit: status %v, retry after %v", w.Code, w.Header().Get("Retry-After"))
+	}
+	// the refused request is not counted in the quota of 4
+	if w := request("/api/block", model.RolePublic); w.Code != http.StatusOK || w.Header().Get("X-Quota-Remaining") != "0" {
+		t.Fatalf("last of the quota: status %v, %v remaining", w.Code, w.Header().Get("X-Quota-Remaining"))
+	}
+	if w := request("/api/exec/sql", model.RolePublic); w.Code != http.StatusTooManyRequests || w.Header().Get("X-Quota-Remaining") != "0" {
+		t.Fatalf("over the quota: status %v", w.Code)
+	}
+	for i := 0; i < 5; i++ {
+		if w := request("/home", model.RoleAdmin); w.Code != http.StatusOK {
+			t.Fatal("admin limited", w.Code)
+		}
+	}
+}
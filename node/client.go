@@ -2,19 +2,90 @@ package node
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 
 	"server/common/types"
 )
 
 // Client defines typed wrappers for the Ethereum RPC API.
+// The calls are sent to the healthiest node of the pool and fail over to the others when the node is unreachable
 type Client struct {
-	*RPC
+	pool *Pool
+	// pinned is the node all the calls are sent to, see Pin
+	pinned *endpoint
 }
 
-// Dial connects a client to the given URL.
-func Dial(rawurl string) (*Client, error) {
-	rpc, err := NewRPC(rawurl)
-	return &Client{rpc}, err
+// Dial connects a client to the given comma-separated URLs.
+func Dial(rawurls string) (*Client, error) {
+	pool, err := NewPool(rawurls)
+	return &Client{pool: pool}, err
+}
+
+// Close stops the health checks and closes the connections of the client, and of the clients pinned from it
+func (c *Client) Close() {
+	c.pool.Close()
+}
+
+// Pin returns a client sending all the calls to one healthy node that has reached the block number, so that the
+// calls of the same block see a consistent state. The calls of the pinned client do not fail over. When no node
+// has reached the block, the client is returned unpinned
+func (c *Client) Pin(number types.Long) *Client {
+	// the healthy nodes at the block come first
+	if candidates := c.pool.candidates(number); len(candidates) > 0 {
+		if _, head, healthy := candidates[0].state(); healthy && head >= number {
+			return &Client{pool: c.pool, pinned: candidates[0]}
+		}
+	}
+	return c
+}
+
+// SetBatchSize sets the maximum number of requests sent in one batch, larger batches are split into chunks
+func (c *Client) SetBatchSize(size int) {
+	c.pool.SetBatchSize(size)
+}
+
+func (c *Client) do(ctx context.Context, call func(*RPC) error) error {
+	if c.pinned != nil {
+		return c.pool.do(ctx, []*endpoint{c.pinned}, call)
+	}
+	return c.pool.do(ctx, c.pool.candidates(-1), call)
+}
+
+func (c *Client) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *Client) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return c.do(ctx, func(rpc *RPC) error {
+		return rpc.CallContext(ctx, result, method, args...)
+	})
+}
+
+func (c *Client) BatchCall(b []BatchElem) error {
+	return c.BatchCallContext(context.Background(), b)
+}
+
+func (c *Client) BatchCallContext(ctx context.Context, b []BatchElem) error {
+	return c.do(ctx, func(rpc *RPC) error {
+		return rpc.BatchCallContext(ctx, b)
+	})
+}
+
+// Subscribe subscribes on the first node that supports notifications
+func (c *Client) Subscribe(ctx context.Context, namespace string, args ...interface{}) (sub *subscription, err error) {
+	candidates := c.pool.candidates(-1)
+	if c.pinned != nil {
+		candidates = []*endpoint{c.pinned}
+	}
+	err = errors.New("no node available")
+	for _, e := range candidates {
+		rpc, _, _ := e.state()
+		if sub, err = rpc.Subscribe(ctx, namespace, args...); err == nil || ctx.Err() != nil {
+			return
+		}
+	}
+	return
 }
 
 func (c *Client) BlockNumber(ctx context.Context) (result types.Long, err error) {
@@ -26,3 +97,43 @@ func (c *Client) CallContract(ctx context.Context, to, data, number any) (result
 	err = c.CallContext(ctx, &result, "eth_call", map[string]any{"to": to, "data": data}, number)
 	return
 }
+
+// SubscribeNewHead subscribes to newHeads and sends the number of each new head to the returned channel,
+// the channel is closed when the subscription or the connection ends. HTTP connections are not supported
+func (c *Client) SubscribeNewHead(ctx context.Context) (<-chan types.Long, error) {
+	sub, err := c.Subscribe(ctx, "eth", "newHeads")
+	if err != nil {
+		return nil, err
+	}
+	heads := make(chan types.Long)
+	go func() {
+		defer close(heads)
+		for {
+			select {
+			case n, ok := <-sub.Ch():
+				if !ok {
+					return
+				}
+				params, header := SubscriptionParams{}, struct {
+					Number types.Long `json:"number"`
+				}{}
+				if err := n.UnmarshalParamsInto(&params); err != nil {
+					continue
+				}
+				if err := json.Unmarshal(params.Result, &header); err != nil {
+					continue
+				}
+				select {
+				case heads <- header.Number:
+				case <-ctx.Done():
+					_ = sub.Unsubscribe(context.Background())
+					return
+				}
+			case <-ctx.Done():
+				_ = sub.Unsubscribe(context.Background())
+				return
+			}
+		}
+	}()
+	return heads, nil
+}
@@ -17,13 +17,18 @@ import (
 )
 
 func newLoopingTransport(ctx context.Context, conn connCloser, readMessage readMessageFunc, writeMessage writeMessageFunc) *loopingTransport {
+	// the transport context is cancelled when the loop ends, so that the pending and later requests fail fast
+	ctx, cancel := context.WithCancel(ctx)
 	t := loopingTransport{
 		conn:                   conn,
 		ctx:                    ctx,
+		cancel:                 cancel,
 		counter:                rand.Uint64(),
 		chToBackend:            make(chan jsonrpc.Request),
 		chSubscriptionRequests: make(chan *subscriptionRequest),
 		chOutboundRequests:     make(chan *outboundRequest),
+		chBatchToBackend:       make(chan []jsonrpc.Request),
+		chOutboundBatches:      make(chan []*outboundRequest),
 		subscriptonRequests:    make(map[jsonrpc.ID]*subscriptionRequest),
 		outboundRequests:       make(map[jsonrpc.ID]*outboundRequest),
 		subscriptions:          make(map[string]*subscription),
@@ -69,16 +74,21 @@ type outboundRequest struct {
 	chResult    chan *jsonrpc.RawResponse
 	chError     chan error
 	chAbandoned chan struct{}
+	// batched is set for the requests sent in a batch, the node may answer a batch with one error without id
+	batched bool
 }
 
 type loopingTransport struct {
-	conn connCloser
-	ctx  context.Context
+	conn   connCloser
+	ctx    context.Context
+	cancel context.CancelFunc
 
 	counter                uint64
 	chToBackend            chan jsonrpc.Request
 	chSubscriptionRequests chan *subscriptionRequest
 	chOutboundRequests     chan *outboundRequest
+	chBatchToBackend       chan []jsonrpc.Request
+	chOutboundBatches      chan []*outboundRequest
 
 	subscriptonRequests map[jsonrpc.ID]*subscriptionRequest
 	outboundRequests    map[jsonrpc.ID]*outboundRequest
@@ -116,114 +126,136 @@ func (t *loopingTransport) loop() {
 			}
 			// log.Printf("[SPAM] read: %s", string(payload))
 
-			// is it a request, notification, or response?
-			msg, err := jsonrpc.Unmarshal(payload)
+			// is it a request, notification, or response? a batch response is an array of responses
+			msgs, err := unmarshalMessages(payload)
 			if err != nil {
 				return errors.Wrap(err, "unrecognized message from backend websocket connection")
 			}
 
-			switch msg := msg.(type) {
-			case *jsonrpc.RawResponse:
-				// log.Printf("[SPAM] response: %p", msg)
+			for _, msg := range msgs {
+				switch msg := msg.(type) {
+				case *jsonrpc.RawResponse:
+					// log.Printf("[SPAM] response: %p", msg)
 
-				// subscriptions
-				t.requestMu.Lock()
-				if start, ok := t.subscriptonRequests[msg.ID]; ok {
-					delete(t.subscriptonRequests, msg.ID)
-					t.requestMu.Unlock()
+					// subscriptions
+					t.requestMu.Lock()
+					if start, ok := t.subscriptonRequests[msg.ID]; ok {
+						delete(t.subscriptonRequests, msg.ID)
+						t.requestMu.Unlock()
 
-					patchedResponse := *msg
-					patchedResponse.ID = start.request.ID
+						patchedResponse := *msg
+						patchedResponse.ID = start.request.ID
 
-					if patchedResponse.Result == nil || patchedResponse.Error != nil {
-						select {
-						case <-ctx.Done():
-							continue
-						case start.chError <- errors.New("Error w/ subscription"):
-							continue
+						if patchedResponse.Result == nil || patchedResponse.Error != nil {
+							select {
+							case <-ctx.Done():
+								continue
+							case start.chError <- errors.New("Error w/ subscription"):
+								continue
+							}
 						}
-					}
 
-					var result interface{}
-					err = json.Unmarshal(patchedResponse.Result, &result)
-					if err != nil {
-						return errors.Wrap(err, "unparsable result from backend websocket connection")
+						var result interface{}
+						err = json.Unmarshal(patchedResponse.Result, &result)
+						if err != nil {
+							return errors.Wrap(err, "unparsable result from backend websocket connection")
+						}
+
+						// log.Printf("[SPAM]: Result: %v", result)
+
+						switch result := result.(type) {
+						case string:
+							sub := newSubscription(&patchedResponse, result, t)
+							t.subscriptionsMu.Lock()
+							t.subscriptions[result] = sub
+							t.subscriptionsMu.Unlock()
+
+							go func() {
+								select {
+								case <-ctx.Done():
+									return
+								case start.chResult <- sub:
+									return
+								}
+							}()
+							continue
+						default:
+							select {
+							case <-ctx.Done():
+								continue
+							case start.chError <- errors.New("Non-string subscription id"):
+								continue
+							}
+						}
 					}
 
-					// log.Printf("[SPAM]: Result: %v", result)
+					// other responses
+					if outbound, ok := t.outboundRequests[msg.ID]; ok {
+						delete(t.outboundRequests, msg.ID)
+						t.requestMu.Unlock()
 
-					switch result := result.(type) {
-					case string:
-						sub := newSubscription(&patchedResponse, result, t)
-						t.subscriptionsMu.Lock()
-						t.subscriptions[result] = sub
-						t.subscriptionsMu.Unlock()
-
-						go func() {
+						go func(o *outboundRequest, r *jsonrpc.RawResponse) {
+							patchedResponse := *r
+							patchedResponse.ID = o.request.ID
 							select {
 							case <-ctx.Done():
 								return
-							case start.chResult <- sub:
+							case <-o.chAbandoned:
+								// request was abandoned (e.g. client disconnected)
+								log.Printf("[WARN] request abandoned %v %v", r.ID, o.request.ID)
+								return
+							case o.chResult <- &patchedResponse:
 								return
 							}
-						}()
+
+						}(outbound, msg)
 						continue
-					default:
-						select {
-						case <-ctx.Done():
-							continue
-						case start.chError <- errors.New("Non-string subscription id"):
-							continue
+					}
+					// an error without id answers a batch the node refused as a whole, the batch it belongs to is
+					// unknown, so all the pending batches fail
+					var failed []*outboundRequest
+					if msg.Error != nil && msg.ID == (jsonrpc.ID{}) {
+						for id, outbound := range t.outboundRequests {
+							if outbound.batched {
+								failed = append(failed, outbound)
+								delete(t.outboundRequests, id)
+							}
 						}
 					}
-				}
-
-				// other responses
-				if outbound, ok := t.outboundRequests[msg.ID]; ok {
-					delete(t.outboundRequests, msg.ID)
 					t.requestMu.Unlock()
+					for _, outbound := range failed {
+						go func(o *outboundRequest, err error) {
+							select {
+							case <-ctx.Done():
+							case <-o.chAbandoned:
+							case o.chError <- err:
+							}
+						}(outbound, newError(*msg.Error))
+					}
 
-					go func(o *outboundRequest, r *jsonrpc.RawResponse) {
-						patchedResponse := *r
-						patchedResponse.ID = o.request.ID
-						select {
-						case <-ctx.Done():
-							return
-						case <-o.chAbandoned:
-							// request was abandoned (e.g. client disconnected)
-							log.Printf("[WARN] request abandoned %v %v", r.ID, o.request.ID)
-							return
-						case o.chResult <- &patchedResponse:
-							return
-						}
-
-					}(outbound, msg)
-					continue
-				}
-				t.requestMu.Unlock()
+				case *jsonrpc.Request:
+					// log.Printf("[SPAM] request: %v", msg)
+				case *jsonrpc.Notification:
+					// log.Printf("[SPAM] notif: %v", msg)
+					if msg.Method != "eth_subscription" {
+						continue
+					}
 
-			case *jsonrpc.Request:
-				// log.Printf("[SPAM] request: %v", msg)
-			case *jsonrpc.Notification:
-				// log.Printf("[SPAM] notif: %v", msg)
-				if msg.Method != "eth_subscription" {
-					continue
-				}
+					sp := SubscriptionParams{}
+					err := json.Unmarshal(msg.Params, &sp)
+					if err != nil {
+						log.Printf("[WARN] eth_subscription Notification not decoded: %v", err)
+						continue
+					}
 
-				sp := SubscriptionParams{}
-				err := json.Unmarshal(msg.Params, &sp)
-				if err != nil {
-					log.Printf("[WARN] eth_subscription Notification not decoded: %v", err)
-					continue
+					go func(n jsonrpc.Notification) {
+						t.subscriptionsMu.RLock()
+						defer t.subscriptionsMu.RUnlock()
+						if subscription, ok := t.subscriptions[sp.Subscription]; ok {
+							subscription.dispatch(ctx, n)
+						}
+					}(*msg)
 				}
-
-				go func(n jsonrpc.Notification) {
-					t.subscriptionsMu.RLock()
-					defer t.subscriptionsMu.RUnlock()
-					if subscription, ok := t.subscriptions[sp.Subscription]; ok {
-						subscription.dispatch(ctx, n)
-					}
-				}(*msg)
 			}
 		}
 	})
@@ -266,6 +298,23 @@ func (t *loopingTransport) loop() {
 					return errors.Wrap(err, "error writing to backend websocket connection")
 				}
 
+			case batch := <-t.chBatchToBackend:
+				b, err := json.Marshal(batch)
+				if err != nil {
+					return errors.Wrap(err, "error marshalling batch request for backend")
+				}
+
+				t.writeMu.Lock()
+				err = t.writeMessage(b)
+				t.writeMu.Unlock()
+				if err != nil {
+					if ctx.Err() == context.Canceled {
+						return nil
+					}
+
+					return errors.Wrap(err, "error writing to backend websocket connection")
+				}
+
 			case <-ctx.Done():
 				return nil
 			}
@@ -313,6 +362,25 @@ func (t *loopingTransport) loop() {
 					continue
 				}
 
+			// outbound batch requests, all the requests are written in one message
+			case batch := <-t.chOutboundBatches:
+				proxies := make([]jsonrpc.Request, len(batch))
+				t.requestMu.Lock()
+				for i, o := range batch {
+					id := t.nextID(o.request.ID)
+					proxies[i] = *o.request
+					proxies[i].ID = id
+					t.outboundRequests[id] = o
+				}
+				t.requestMu.Unlock()
+
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case t.chBatchToBackend <- proxies:
+					continue
+				}
+
 			case <-ctx.Done():
 				return nil
 			}
@@ -344,6 +412,7 @@ func (t *loopingTransport) loop() {
 	t.subscriptionsMu.Unlock()
 
 	_ = t.conn.Close()
+	t.cancel()
 }
 
 func (t *loopingTransport) nextID(seed jsonrpc.ID) jsonrpc.ID {
@@ -404,6 +473,117 @@ func (t *loopingTransport) Request(ctx context.Context, r *jsonrpc.Request) (*js
 	}
 }
 
+func (t *loopingTransport) BatchRequest(ctx context.Context, rs []*jsonrpc.Request) ([]*jsonrpc.RawResponse, error) {
+	select {
+	case <-t.ctx.Done():
+		return nil, errors.Wrap(t.ctx.Err(), "transport context finished")
+	default:
+		// transport context is still valid, we can process this batch
+	}
+
+	batch := make([]*outboundRequest, len(rs))
+	abandoned := make(chan struct{})
+	defer close(abandoned)
+	for i, r := range rs {
+		owned, err := copyRequest(r)
+		if err != nil {
+			return nil, err
+		}
+		batch[i] = &outboundRequest{
+			request:     &owned,
+			chResult:    make(chan *jsonrpc.RawResponse),
+			chError:     make(chan error),
+			chAbandoned: abandoned,
+			batched:     true,
+		}
+	}
+
+	select {
+	case t.chOutboundBatches <- batch:
+	case <-t.ctx.Done():
+		return nil, errors.Wrap(t.ctx.Err(), "transport context finished waiting for response")
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "context finished waiting for response")
+	}
+
+	responses := make([]*jsonrpc.RawResponse, len(batch))
+	for i, outbound := range batch {
+		select {
+		case responses[i] = <-outbound.chResult:
+		case err := <-outbound.chError:
+			return nil, err
+		case <-t.ctx.Done():
+			return nil, errors.Wrap(t.ctx.Err(), "transport context finished waiting for response")
+		case <-ctx.Done():
+			return nil, errors.Wrap(ctx.Err(), "context finished waiting for response")
+		}
+	}
+	return responses, nil
+}
+
+func (t *loopingTransport) Subscribe(ctx context.Context, r *jsonrpc.Request) (*subscription, error) {
+	select {
+	case <-t.ctx.Done():
+		return nil, errors.Wrap(t.ctx.Err(), "transport context finished")
+	default:
+		// transport context is still valid, we can process this subscription
+	}
+
+	owned, err := copyRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	start := &subscriptionRequest{
+		request:  &owned,
+		chResult: make(chan *subscription),
+		chError:  make(chan error),
+	}
+
+	select {
+	case t.chSubscriptionRequests <- start:
+	case <-t.ctx.Done():
+		return nil, errors.Wrap(t.ctx.Err(), "transport context finished waiting for subscription")
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "context finished waiting for subscription")
+	}
+
+	select {
+	case sub := <-start.chResult:
+		return sub, nil
+	case err := <-start.chError:
+		return nil, err
+	case <-t.ctx.Done():
+		return nil, errors.Wrap(t.ctx.Err(), "transport context finished waiting for subscription")
+	case <-ctx.Done():
+		return nil, errors.Wrap(ctx.Err(), "context finished waiting for subscription")
+	}
+}
+
+// unmarshalMessages decodes a single message or a batch of messages
+func unmarshalMessages(payload []byte) ([]interface{}, error) {
+	if trimmed := bytes.TrimLeft(payload, " \t\r\n"); len(trimmed) == 0 || trimmed[0] != '[' {
+		msg, err := jsonrpc.Unmarshal(payload)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{msg}, nil
+	}
+
+	var raws []json.RawMessage
+	if err := json.Unmarshal(payload, &raws); err != nil {
+		return nil, err
+	}
+	msgs := make([]interface{}, len(raws))
+	for i, raw := range raws {
+		msg, err := jsonrpc.Unmarshal(raw)
+		if err != nil {
+			return nil, err
+		}
+		msgs[i] = msg
+	}
+	return msgs, nil
+}
+
 func copyRequest(request *jsonrpc.Request) (jsonrpc.Request, error) {
 	copied := jsonrpc.Request{}
 	buf := &bytes.Buffer{}
@@ -416,6 +596,16 @@ func copyRequest(request *jsonrpc.Request) (jsonrpc.Request, error) {
 	return copied, nil
 }
 
+// Close ends the loop and closes the connection
+func (t *loopingTransport) Close() {
+	t.cancel()
+}
+
+// Closed reports whether the connection has ended and the transport can no longer be used
+func (t *loopingTransport) Closed() bool {
+	return t.ctx.Err() != nil
+}
+
 func (t *loopingTransport) IsBidirectional() bool {
 	return true
 }
@@ -0,0 +1,230 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"server/common/types"
+)
+
+const (
+	// healthInterval is the interval of the eth_blockNumber health check of each endpoint
+	healthInterval = 5 * time.Second
+	// healthTimeout is the time an endpoint has to answer the health check
+	healthTimeout = 3 * time.Second
+	// maxLag is the number of blocks an endpoint may be behind the best head and still receive calls first
+	maxLag = 2
+)
+
+// endpoint is one node of the pool and its last known health
+type endpoint struct {
+	url string
+
+	mu      sync.RWMutex
+	rpc     *RPC
+	head    types.Long
+	healthy bool
+	// checking is set while the endpoint is checked, a check still running is not started again
+	checking atomic.Bool
+}
+
+func (e *endpoint) state() (rpc *RPC, head types.Long, healthy bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.rpc, e.head, e.healthy
+}
+
+func (e *endpoint) fail(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.healthy {
+		log.Printf("node %s is unhealthy: %v\n", e.url, err)
+	}
+	e.healthy = false
+}
+
+// Pool is the set of nodes the client sends calls to, the nodes are health checked in the background
+type Pool struct {
+	endpoints []*endpoint
+	batchSize atomic.Int64
+	next      atomic.Uint64
+	// ctx ends the health checks when the pool is closed
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewPool connects to the comma-separated node urls, it fails only when no node can be connected
+func NewPool(rawurls string) (*Pool, error) {
+	p := &Pool{}
+	p.ctx, p.cancel = context.WithCancel(context.Background())
+	p.batchSize.Store(DefaultBatchSize)
+	for _, rawurl := range strings.Split(rawurls, ",") {
+		if rawurl = strings.TrimSpace(rawurl); rawurl != "" {
+			p.endpoints = append(p.endpoints, &endpoint{url: rawurl})
+		}
+	}
+	if len(p.endpoints) == 0 {
+		p.cancel()
+		return nil, errors.New("no node url")
+	}
+	var wg sync.WaitGroup
+	for _, e := range p.endpoints {
+		wg.Add(1)
+		go func(e *endpoint) {
+			defer wg.Done()
+			p.check(e)
+		}(e)
+	}
+	wg.Wait()
+	var errs []string
+	for _, e := range p.endpoints {
+		if rpc, _, _ := e.state(); rpc == nil {
+			errs = append(errs, e.url)
+		}
+	}
+	if len(errs) == len(p.endpoints) {
+		p.Close()
+		return nil, fmt.Errorf("could not connect to any node: %s", strings.Join(errs, ", "))
+	}
+	go p.monitor()
+	return p, nil
+}
+
+// SetBatchSize sets the batch size of all the nodes, including the ones dialed again later
+func (p *Pool) SetBatchSize(size int) {
+	if size < 1 {
+		size = DefaultBatchSize
+	}
+	p.batchSize.Store(int64(size))
+	for _, e := range p.endpoints {
+		if rpc, _, _ := e.state(); rpc != nil {
+			rpc.SetBatchSize(size)
+		}
+	}
+}
+
+// Close stops the health checks and closes the connections to the nodes, the pool can no longer be used
+func (p *Pool) Close() {
+	p.cancel()
+	for _, e := range p.endpoints {
+		e.mu.Lock()
+		if e.rpc != nil {
+			e.rpc.Close()
+		}
+		e.mu.Unlock()
+	}
+}
+
+func (p *Pool) monitor() {
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for _, e := range p.endpoints {
+				go p.check(e)
+			}
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// check dials the endpoint again when its connection is gone, then measures its head and latency
+func (p *Pool) check(e *endpoint) {
+	if !e.checking.CompareAndSwap(false, true) {
+		return
+	}
+	defer e.checking.Store(false)
+	rpc, _, _ := e.state()
+	if rpc == nil || rpc.Closed() {
+		var err error
+		if rpc, err = NewRPC(e.url); err != nil {
+			e.fail(err)
+			return
+		}
+		rpc.SetBatchSize(int(p.batchSize.Load()))
+		e.mu.Lock()
+		if p.ctx.Err() != nil {
+			// closed while dialing
+			e.mu.Unlock()
+			rpc.Close()
+			return
+		}
+		e.rpc = rpc
+		e.mu.Unlock()
+	}
+	ctx, cancel := context.WithTimeout(p.ctx, healthTimeout)
+	defer cancel()
+	var head types.Long
+	if err := rpc.CallContext(ctx, &head, "eth_blockNumber"); err != nil {
+		if p.ctx.Err() == nil {
+			e.fail(err)
+		}
+		return
+	}
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if !e.healthy {
+		log.Printf("node %s is healthy at block %d\n", e.url, head)
+	}
+	e.head, e.healthy = head, true
+}
+
+// candidates returns the endpoints in the order they should be tried: the healthy ones close to the best head
+// in round-robin order, then the lagging ones by head, then the unhealthy ones as the last resort.
+// Endpoints below the given block number are only used when no other endpoint is left
+func (p *Pool) candidates(number types.Long) []*endpoint {
+	best := types.Long(-1)
+	for _, e := range p.endpoints {
+		if _, head, healthy := e.state(); healthy && head > best {
+			best = head
+		}
+	}
+	var synced, lagging, rest []*endpoint
+	heads := make(map[*endpoint]types.Long)
+	start := int(p.next.Add(1))
+	for i := range p.endpoints {
+		e := p.endpoints[(start+i)%len(p.endpoints)]
+		rpc, head, healthy := e.state()
+		switch {
+		case rpc == nil:
+			continue
+		case healthy && head >= number && head+maxLag >= best:
+			synced = append(synced, e)
+		case healthy && head >= number:
+			lagging, heads[e] = append(lagging, e), head
+		default:
+			rest = append(rest, e)
+		}
+	}
+	sort.SliceStable(lagging, func(i, j int) bool {
+		return heads[lagging[i]] > heads[lagging[j]]
+	})
+	return append(append(synced, lagging...), rest...)
+}
+
+// do runs the call on the candidates until one of them answers, only connection failures move on to the next node,
+// errors returned by the node itself are final
+func (p *Pool) do(ctx context.Context, candidates []*endpoint, call func(*RPC) error) (err error) {
+	err = errors.New("no node available")
+	for _, e := range candidates {
+		rpc, _, _ := e.state()
+		if err = call(rpc); err == nil || ctx.Err() != nil {
+			return
+		}
+		var connErr *connError
+		if !errors.As(err, &connErr) {
+			return
+		}
+		e.fail(err)
+	}
+	return
+}
@@ -19,9 +19,42 @@ import (
 	"server/node/jsonrpc"
 )
 
+// DefaultBatchSize is the maximum number of requests sent in one batch by default
+const DefaultBatchSize = 100
+
 type RPC struct {
 	transport transport
 	rawURL    string
+	batchSize int
+}
+
+// Error is the error object of a JSONRPC response returned by the node
+type Error struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// connError is the failure to exchange messages with the node, the node may be down or unreachable
+type connError struct {
+	error
+}
+
+func (e *connError) Unwrap() error {
+	return e.error
+}
+
+func newError(raw json.RawMessage) error {
+	e := &Error{}
+	if err := json.Unmarshal(raw, e); err != nil || e.Message == "" {
+		// not an error object, the node answered it all the same
+		return &Error{Message: string(raw)}
+	}
+	return e
 }
 
 // NewRPC connects RPC client to the given URL.
@@ -33,6 +66,14 @@ func NewRPC(rawurl string) (*RPC, error) {
 	return client, nil
 }
 
+// SetBatchSize sets the maximum number of requests sent in one batch, larger batches are split into chunks
+func (c *RPC) SetBatchSize(size int) {
+	if size < 1 {
+		size = DefaultBatchSize
+	}
+	c.batchSize = size
+}
+
 func NewClient(ctx context.Context, rawURL string) (*RPC, error) {
 	parsedURL, err := url.Parse(rawURL)
 	if err != nil {
@@ -57,6 +98,7 @@ func NewClient(ctx context.Context, rawURL string) (*RPC, error) {
 	return &RPC{
 		transport: transport,
 		rawURL:    rawURL,
+		batchSize: DefaultBatchSize,
 	}, nil
 }
 
@@ -82,11 +124,11 @@ func (c *RPC) CallContext(ctx context.Context, result interface{}, method string
 
 	response, err := c.Request(ctx, &request)
 	if err != nil {
-		return err
+		return &connError{err}
 	}
 
 	if response.Error != nil {
-		return errors.New(string(*response.Error))
+		return newError(*response.Error)
 	}
 	return json.Unmarshal(response.Result, &result)
 }
@@ -103,16 +145,108 @@ func (c *RPC) BatchCall(b []BatchElem) error {
 	return c.BatchCallContext(ctx, b)
 }
 
+// BatchCallContext sends the elements as JSONRPC batches of at most batchSize requests, the returned error only
+// reports the failure of the transport, the error of each element is set to its Error field
 func (c *RPC) BatchCallContext(ctx context.Context, b []BatchElem) error {
-	for _, elem := range b {
-		elem.Error = c.CallContext(ctx, elem.Result, elem.Method, elem.Args...)
+	for start := 0; start < len(b); start += c.batchSize {
+		end := start + c.batchSize
+		if end > len(b) {
+			end = len(b)
+		}
+		if err := c.batchCall(ctx, b[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *RPC) batchCall(ctx context.Context, b []BatchElem) error {
+	requests := make([]*jsonrpc.Request, len(b))
+	for i, elem := range b {
+		if elem.Result != nil && reflect.TypeOf(elem.Result).Kind() != reflect.Ptr {
+			return fmt.Errorf("call result parameter must be pointer or nil interface: %v", elem.Result)
+		}
+		params, err := jsonrpc.MakeParams(elem.Args...)
+		if err != nil {
+			return err
+		}
+		// the index is used as the ID to match the responses back
+		requests[i] = &jsonrpc.Request{
+			ID:     jsonrpc.ID{Num: uint64(i)},
+			Method: elem.Method,
+			Params: params,
+		}
+	}
+
+	responses, err := c.transport.BatchRequest(ctx, requests)
+	if err != nil {
+		// the node answering the batch with an error is healthy, like the errors of CallContext
+		var rpcErr *Error
+		if errors.As(err, &rpcErr) {
+			return err
+		}
+		return &connError{err}
+	}
+
+	matched := make([]*jsonrpc.RawResponse, len(b))
+	for _, response := range responses {
+		if response != nil && !response.ID.IsString && response.ID.Num < uint64(len(b)) {
+			matched[response.ID.Num] = response
+		}
+	}
+	for i, response := range matched {
+		switch {
+		case response == nil:
+			b[i].Error = errors.New("no response in the batch")
+		case response.Error != nil:
+			b[i].Error = newError(*response.Error)
+		default:
+			b[i].Error = json.Unmarshal(response.Result, b[i].Result)
+		}
 	}
 	return nil
 }
 
+// Subscribe creates a subscription on the node with the namespace_subscribe method, only the bidirectional
+// transports (websocket and IPC) support notifications
+func (c *RPC) Subscribe(ctx context.Context, namespace string, args ...interface{}) (*subscription, error) {
+	t, ok := c.transport.(subscriber)
+	if !ok {
+		return nil, errors.New("notifications not supported")
+	}
+
+	request := jsonrpc.Request{
+		ID:     jsonrpc.ID{Num: 1},
+		Method: namespace + "_subscribe",
+		Params: jsonrpc.MustParams(args...),
+	}
+	return t.Subscribe(ctx, &request)
+}
+
+// Closed reports whether the bidirectional connection has ended, a closed RPC must be dialed again
+func (c *RPC) Closed() bool {
+	t, ok := c.transport.(interface{ Closed() bool })
+	return ok && t.Closed()
+}
+
+// Close closes the connection to the node, the pending calls fail
+func (c *RPC) Close() {
+	if t, ok := c.transport.(interface{ Close() }); ok {
+		t.Close()
+	}
+}
+
 type transport interface {
 	// Request method can be used to send JSONRPC requests and receive JSONRPC responses
 	Request(ctx context.Context, r *jsonrpc.Request) (*jsonrpc.RawResponse, error)
+
+	// BatchRequest method sends the requests in one array-form JSONRPC batch and returns the responses (in any order)
+	BatchRequest(ctx context.Context, rs []*jsonrpc.Request) ([]*jsonrpc.RawResponse, error)
+}
+
+type subscriber interface {
+	// Subscribe method sends the subscription request and returns the subscription created by the backend
+	Subscribe(ctx context.Context, r *jsonrpc.Request) (*subscription, error)
 }
 
 func newHTTPTransport(ctx context.Context, parsedURL *url.URL) (transport, error) {
@@ -147,17 +281,49 @@ func (t *httpTransport) Request(ctx context.Context, r *jsonrpc.Request) (*jsonr
 	return &jr, nil
 }
 
-func (t *httpTransport) dispatchBytes(ctx context.Context, input []byte) ([]byte, error) {
-	t.once.Do(func() {
-		// Since this client is only ever used to access a single endpoint,
-		// we allow all the idle connections to point that host
-		tr := http.DefaultTransport.(*http.Transport).Clone()
-		tr.MaxIdleConnsPerHost = tr.MaxIdleConns
-		t.client = &http.Client{
-			Timeout:   120 * time.Second,
-			Transport: tr,
+func (t *httpTransport) BatchRequest(ctx context.Context, rs []*jsonrpc.Request) ([]*jsonrpc.RawResponse, error) {
+	b, err := json.Marshal(rs)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode batch request json")
+	}
+
+	body, err := t.dispatchBytes(ctx, b)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not dispatch batch request")
+	}
+
+	var jrs []*jsonrpc.RawResponse
+	if err = json.Unmarshal(body, &jrs); err != nil {
+		// the whole batch is rejected with a single response, e.g. the batch is too large
+		jr := jsonrpc.RawResponse{}
+		if json.Unmarshal(body, &jr) == nil && jr.Error != nil {
+			return nil, newError(*jr.Error)
 		}
-	})
+		return nil, errors.Wrap(err, "could not decode batch response json")
+	}
+
+	return jrs, nil
+}
+
+func (t *httpTransport) init() {
+	// Since this client is only ever used to access a single endpoint,
+	// we allow all the idle connections to point that host
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.MaxIdleConnsPerHost = tr.MaxIdleConns
+	t.client = &http.Client{
+		Timeout:   120 * time.Second,
+		Transport: tr,
+	}
+}
+
+// Close closes the idle connections, the requests still running are not interrupted
+func (t *httpTransport) Close() {
+	t.once.Do(t.init)
+	t.client.CloseIdleConnections()
+}
+
+func (t *httpTransport) dispatchBytes(ctx context.Context, input []byte) ([]byte, error) {
+	t.once.Do(t.init)
 
 	r, err := http.NewRequest(http.MethodPost, t.rawURL, bytes.NewReader(input))
 	if err != nil {
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"server/backend"
+	"server/common/model"
+	"server/common/types"
+	"server/conf"
+	"server/middleware"
+	"server/service"
+)
+
+func Admin(e *gin.Engine) {
+	g := e.Group("/admin", middleware.Require(model.RoleAdmin))
+	g.POST("/reindex", startReindex)
+	g.GET("/reindex", reindexJobs)
+	g.POST("/abi/:addr", saveABI)
+	g.POST("/signature", saveSignatures)
+}
+
+// @Tags        admin
+// @Summary     reindex block range
+// @Description Decode and write the stored blocks from..to again in the background, the chain head keeps advancing
+// @Accept      json
+// @Produce     json
+// @Param       X-API-Key   header   string true "admin API key"
+// @Param       from        query    string true "first block"
+// @Param       to          query    string true "last block"
+// @Success     200         {object} backend.ReindexJob
+// @Failure     400         {object} service.ErrRes
+// @Router      /admin/reindex [post]
+func startReindex(c *gin.Context) {
+	from, err := strconv.ParseInt(c.Query("from"), 0, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: "from error"})
+		return
+	}
+	to, err := strconv.ParseInt(c.Query("to"), 0, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: "to error"})
+		return
+	}
+	job, err := backend.StartReindex(conf.ChainUrl, conf.BatchSize, types.Long(from), types.Long(to))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, job)
+}
+
+// @Tags        admin
+// @Summary     query reindex jobs
+// @Description Query the progress of the reindex jobs started since the service started, the latest first
+// @Accept      json
+// @Produce     json
+// @Param       X-API-Key   header   string true "admin API key"
+// @Success     200         {array}  backend.ReindexJob
+// @Router      /admin/reindex [get]
+func reindexJobs(c *gin.Context) {
+	c.JSON(http.StatusOK, backend.ReindexJobs())
+}
+
+// @Tags        admin
+// @Summary     upload contract ABI
+// @Description Store the ABI JSON of the contract, its transaction input, event logs and custom errors are decoded from then on
+// @Accept      json
+// @Produce     json
+// @Param       X-API-Key   header   string true  "admin API key"
+// @Param       addr        path     string true  "contract address"
+// @Param       name        query    string false "contract name"
+// @Param       abi         body     string true  "ABI JSON array"
+// @Success     200         {object} model.ContractABI
+// @Failure     400         {object} service.ErrRes
+// @Router      /admin/abi/{addr} [post]
+func saveABI(c *gin.Context) {
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	var name *string
+	if n := c.Query("name"); n != "" {
+		name = &n
+	}
+	res, err := service.SaveABI(c.Param("addr"), name, data)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// @Tags        admin
+// @Summary     add text signatures
+// @Description Add function and event text signatures to the built-in signature database, the method and event names of the transactions and logs are looked up in it
+// @Accept      json
+// @Produce     json
+// @Param       X-API-Key   header   string                 true "admin API key"
+// @Param       signatures  body     []service.SignatureReq true "text signatures"
+// @Success     200         {array}  model.Signature
+// @Failure     400         {object} service.ErrRes
+// @Router      /admin/signature [post]
+func saveSignatures(c *gin.Context) {
+	var reqs []service.SignatureReq
+	if err := c.ShouldBindJSON(&reqs); err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	res, err := service.SaveSignatures(reqs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
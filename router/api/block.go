@@ -24,12 +24,13 @@ func Block(e *gin.Engine) {
 // @Param       filter    query    string false "filter block, 1: black hole; 2: penalty; other: all"
 // @Param       page      query    string false "Page, default 1"
 // @Param       page_size query    string false "Page size, default 10"
+// @Param       cursor    query    string false "Cursor of the page, the next of the previous page, takes the place of page"
 // @Success     200       {object} service.BlocksRes
 // @Failure     400       {object} service.ErrRes
 // @Router      /block/page [get]
 func pageBlock(c *gin.Context) {
 	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
-	data, err := service.FetchBlocks(page, size, c.Query("filter"))
+	data, err := service.FetchBlocks(page, size, c.Query("cursor"), c.Query("filter"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
 		return
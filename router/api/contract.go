@@ -0,0 +1,96 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"server/service"
+)
+
+// Contract contract API
+func Contract(e *gin.Engine) {
+	e.GET("/contract/:addr/abi", getABI)
+	e.GET("/contract/:addr/proxy", getProxyImplementations)
+	e.POST("/contract/:addr/read", readContract)
+	e.GET("/signature/:hash", getSignatures)
+}
+
+// @Tags        contract
+// @Summary     query contract ABI
+// @Description Query the uploaded ABI of the contract
+// @Accept      json
+// @Produce     json
+// @Param       addr path     string true "contract address"
+// @Success     200  {object} model.ContractABI
+// @Failure     400  {object} service.ErrRes
+// @Router      /contract/{addr}/abi [get]
+func getABI(c *gin.Context) {
+	res, err := service.GetABI(c.Param("addr"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// @Tags        contract
+// @Summary     query text signatures
+// @Description Query the known text signatures of a 4 bytes function selector or 32 bytes event topic, more than one when it is ambiguous
+// @Accept      json
+// @Produce     json
+// @Param       hash path    string true "function selector or event topic"
+// @Success     200  {array} string
+// @Router      /signature/{hash} [get]
+func getSignatures(c *gin.Context) {
+	res := service.GetSignatures(c.Param("hash"))
+	if res == nil {
+		res = []string{}
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// @Tags        contract
+// @Summary     query proxy implementations
+// @Description Query the implementation history of the proxy contract, the latest first, a beacon proxy also lists the upgrades of its beacon. The calls and logs of a proxy without its own ABI are decoded with the ABI of the current implementation
+// @Accept      json
+// @Produce     json
+// @Param       addr path     string true "proxy contract address"
+// @Success     200  {array}  model.ProxyImplementation
+// @Failure     400  {object} service.ErrRes
+// @Router      /contract/{addr}/proxy [get]
+func getProxyImplementations(c *gin.Context) {
+	res, err := service.FetchProxyImplementations(c.Param("addr"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// @Tags        contract
+// @Summary     read contract
+// @Description Call a view function of the contract with eth_call at the block, encoded and decoded with the given or the uploaded ABI
+// @Accept      json
+// @Produce     json
+// @Param       addr path     string                  true "contract address"
+// @Param       call body     service.ReadContractReq true "function, arguments and block"
+// @Success     200  {object} service.ReadContractRes
+// @Failure     400  {object} service.ErrRes
+// @Router      /contract/{addr}/read [post]
+func readContract(c *gin.Context) {
+	var req service.ReadContractReq
+	// keep the numbers exact, they may exceed the float64 precision
+	decoder := json.NewDecoder(c.Request.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&req); err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	res, err := service.ReadContract(c.Request.Context(), c.Param("addr"), req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -2,14 +2,16 @@ package api
 
 import (
 	"net/http"
+	"server/common/model"
 	"server/common/utils"
+	"server/middleware"
 
 	"github.com/gin-gonic/gin"
 	"server/service"
 )
 
 func Extra(e *gin.Engine) {
-	e.GET("/exec_sql", execSql)
+	e.GET("/exec_sql", middleware.Require(model.RoleAdmin), execSql)
 	e.GET("/erb_price", erbPrice)
 	e.GET("/slashings", pageSlashing)
 }
@@ -19,16 +21,12 @@ func Extra(e *gin.Engine) {
 // @Description execute sql statement and return the result, only read
 // @Accept      json
 // @Produce     json
-// @Param       key query    string false "admin key"
-// @Param       sql query    string false "sql statement"
-// @Success     200 {array}  string
-// @Failure     400 {object} service.ErrRes
+// @Param       X-API-Key header   string true  "admin API key"
+// @Param       sql       query    string false "sql statement"
+// @Success     200       {array}  string
+// @Failure     400       {object} service.ErrRes
 // @Router      /exec_sql [get]
 func execSql(c *gin.Context) {
-	if c.Query("key") != "123456789kd.wl" {
-		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: "key error, not allow"})
-		return
-	}
 	res, err := service.ExecSql(c.Query("sql"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
@@ -0,0 +1,71 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"server/common/utils"
+	"server/service"
+)
+
+func Reorg(e *gin.Engine) {
+	e.GET("/reorg/page", pageReorg)
+	e.GET("/reorg/:id", getReorg)
+	e.GET("/transaction/orphaned/:hash", getOrphanedTx)
+}
+
+// @Tags        reorg
+// @Summary     query reorg list
+// @Description Query the chain reorganizations in reverse order
+// @Accept      json
+// @Produce     json
+// @Param       page      query    string false "Page, default 1"
+// @Param       page_size query    string false "Page size, default 10"
+// @Success     200       {object} service.ReorgsRes
+// @Failure     400       {object} service.ErrRes
+// @Router      /reorg/page [get]
+func pageReorg(c *gin.Context) {
+	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
+	data, err := service.FetchReorgs(page, size)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, data)
+}
+
+// @Tags        reorg
+// @Summary     query reorg
+// @Description Query the chain reorganization with its orphaned transactions
+// @Accept      json
+// @Produce     json
+// @Param       id  path     string true "reorg id"
+// @Success     200 {object} service.ReorgRes
+// @Failure     400 {object} service.ErrRes
+// @Router      /reorg/{id} [get]
+func getReorg(c *gin.Context) {
+	data, err := service.GetReorg(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, data)
+}
+
+// @Tags        reorg
+// @Summary     query orphaned transaction
+// @Description Query whether the transaction was ever in an orphaned block, and whether it is in the current chain
+// @Accept      json
+// @Produce     json
+// @Param       hash path     string true "tx hash"
+// @Success     200  {object} service.OrphanedTxRes
+// @Failure     400  {object} service.ErrRes
+// @Router      /transaction/orphaned/{hash} [get]
+func getOrphanedTx(c *gin.Context) {
+	data, err := service.GetOrphanedTx(c.Param("hash"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, data)
+}
@@ -21,12 +21,13 @@ func Reward(e *gin.Engine) {
 // @Produce     json
 // @Param       page      query    string false "Page, default 1"
 // @Param       page_size query    string false "Page size, default 10"
+// @Param       cursor    query    string false "Cursor of the page, the next of the previous page, takes the place of page"
 // @Success     200       {object} service.RewardsRes
 // @Failure     400       {object} service.ErrRes
 // @Router      /reward [get]
 func pageReward(c *gin.Context) {
 	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
-	data, err := service.FetchRewards(page, size)
+	data, err := service.FetchRewards(page, size, c.Query("cursor"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
 		return
@@ -0,0 +1,34 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"server/common/utils"
+	"server/service"
+)
+
+// Search search API
+func Search(e *gin.Engine) {
+	e.GET("/search", search)
+}
+
+// @Tags        search
+// @Summary     search
+// @Description Classify the query as a block number, a transaction or block hash, an address, an SNFT address or an epoch ID and look for it, any other query matches the contract names and symbols by prefix
+// @Accept      json
+// @Produce     json
+// @Param       q         query    string true  "block number, hash, address, SNFT address, epoch ID, or name or symbol prefix"
+// @Param       page_size query    string false "maximum number of names and symbols matched, default 10"
+// @Success     200       {object} service.SearchRes
+// @Failure     400       {object} service.ErrRes
+// @Router      /search [get]
+func search(c *gin.Context) {
+	_, size := utils.ParsePagination("", c.Query("page_size"))
+	res, err := service.Search(c.Query("q"), size)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
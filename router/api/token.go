@@ -0,0 +1,165 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"server/common/utils"
+	"server/service"
+)
+
+// Token token API
+func Token(e *gin.Engine) {
+	e.GET("/token/page", pageToken)
+	e.GET("/token/:addr", getToken)
+	e.GET("/token/:addr/holders", pageTokenHolder)
+	e.GET("/token/:addr/inventory", pageInventory)
+	e.GET("/token/:addr/nft/:id", getERC721Token)
+	e.GET("/account/:addr/tokens", pageAccountToken)
+	e.GET("/account/:addr/nfts", pageAccountNFT)
+}
+
+// @Tags        token
+// @Summary     query token list
+// @Description Query the ERC20, ERC721 and ERC1155 token contracts
+// @Accept      json
+// @Produce     json
+// @Param       type      query    string false "token type, ERC20, ERC721 or ERC1155, default all"
+// @Param       order     query    string false "sort by holderCount, transferCount, totalSupply or blockNumber, optionally followed by asc or desc, default holderCount desc"
+// @Param       page      query    string false "Page, default 1"
+// @Param       page_size query    string false "Page size, default 10"
+// @Success     200       {object} service.TokensRes
+// @Failure     400       {object} service.ErrRes
+// @Router      /token/page [get]
+func pageToken(c *gin.Context) {
+	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
+	res, err := service.FetchTokens(page, size, c.Query("type"), c.Query("order"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// @Tags        token
+// @Summary     query token
+// @Description Query the token contract with its decimals, total supply, holder and transfer counts
+// @Accept      json
+// @Produce     json
+// @Param       addr path     string true "token contract address"
+// @Success     200  {object} model.Token
+// @Failure     400  {object} service.ErrRes
+// @Router      /token/{addr} [get]
+func getToken(c *gin.Context) {
+	res, err := service.GetToken(c.Param("addr"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// @Tags        token
+// @Summary     query token holders
+// @Description Query the holders of the token by balance, with their share of the total supply
+// @Accept      json
+// @Produce     json
+// @Param       addr      path     string true  "token contract address"
+// @Param       token_id  query    string false "ERC1155 token ID, default all"
+// @Param       page      query    string false "Page, default 1"
+// @Param       page_size query    string false "Page size, default 10"
+// @Success     200       {object} service.TokenHoldersRes
+// @Failure     400       {object} service.ErrRes
+// @Router      /token/{addr}/holders [get]
+func pageTokenHolder(c *gin.Context) {
+	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
+	res, err := service.FetchTokenHolders(c.Param("addr"), c.Query("token_id"), page, size)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// @Tags        token
+// @Summary     query account tokens
+// @Description Query the token balances of the account
+// @Accept      json
+// @Produce     json
+// @Param       addr      path     string true  "account address"
+// @Param       page      query    string false "Page, default 1"
+// @Param       page_size query    string false "Page size, default 10"
+// @Success     200       {object} service.AccountTokensRes
+// @Failure     400       {object} service.ErrRes
+// @Router      /account/{addr}/tokens [get]
+func pageAccountToken(c *gin.Context) {
+	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
+	res, err := service.FetchAccountTokens(c.Param("addr"), page, size)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// @Tags        token
+// @Summary     query ERC721 collection tokens
+// @Description Query the tokens of the ERC721 collection that are not burnt, with their owners
+// @Accept      json
+// @Produce     json
+// @Param       addr      path     string true  "token contract address"
+// @Param       owner     query    string false "owner address, default all"
+// @Param       page      query    string false "Page, default 1"
+// @Param       page_size query    string false "Page size, default 10"
+// @Success     200       {object} service.InventoryRes
+// @Failure     400       {object} service.ErrRes
+// @Router      /token/{addr}/inventory [get]
+func pageInventory(c *gin.Context) {
+	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
+	res, err := service.FetchInventory(c.Param("addr"), c.Query("owner"), page, size)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// @Tags        token
+// @Summary     query ERC721 token
+// @Description Query the owner of the ERC721 token and all its transfers since the mint
+// @Accept      json
+// @Produce     json
+// @Param       addr path     string true "token contract address"
+// @Param       id   path     string true "token ID, decimal"
+// @Success     200  {object} service.ERC721TokenRes
+// @Failure     400  {object} service.ErrRes
+// @Router      /token/{addr}/nft/{id} [get]
+func getERC721Token(c *gin.Context) {
+	res, err := service.GetERC721Token(c.Param("addr"), c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// @Tags        token
+// @Summary     query account ERC721 tokens
+// @Description Query the ERC721 tokens owned by the account across all the collections
+// @Accept      json
+// @Produce     json
+// @Param       addr      path     string true  "account address"
+// @Param       page      query    string false "Page, default 1"
+// @Param       page_size query    string false "Page size, default 10"
+// @Success     200       {object} service.AccountNFTsRes
+// @Failure     400       {object} service.ErrRes
+// @Router      /account/{addr}/nfts [get]
+func pageAccountNFT(c *gin.Context) {
+	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
+	res, err := service.FetchAccountNFTs(c.Param("addr"), page, size)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -15,8 +15,12 @@ func Transaction(e *gin.Engine) {
 	e.GET("/transaction_logs/:hash", getTransactionLogs)
 	e.GET("/transaction/internal/page", pageInternalTransaction)
 	e.GET("/transaction/internal/:hash", getInternalTransaction)
+	e.GET("/transaction/internal/:hash/tree", getInternalTransactionTree)
 	e.GET("/transaction/erbie/page", pageErbieTransaction)
 	e.GET("/transaction/erbie/:hash", getErbieTransaction)
+	e.GET("/transaction/erc20/page", pageERC20Transfer)
+	e.GET("/transaction/erc721/page", pageERC721Transfer)
+	e.GET("/transaction/erc1155/page", pageERC1155Transfer)
 }
 
 // @Tags        transaction
@@ -26,6 +30,7 @@ func Transaction(e *gin.Engine) {
 // @Produce     json
 // @Param       page      query    string false "Page, default 1"
 // @Param       page_size query    string false "Page size, default 10"
+// @Param       cursor    query    string false "Cursor of the page, the next of the previous page, takes the place of page"
 // @Param       number    query    string false "Block number, if empty, query all"
 // @Param       addr      query    string false "Account address, if empty, query all"
 // @Param       types     query    string false "erbie tx type,supports multiple types,if empty, query all"
@@ -34,7 +39,7 @@ func Transaction(e *gin.Engine) {
 // @Router      /transaction/page [get]
 func pageTransaction(c *gin.Context) {
 	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
-	data, err := service.FetchTransactions(page, size, c.Query("number"), c.Query("addr"), c.Query("types"))
+	data, err := service.FetchTransactions(page, size, c.Query("cursor"), c.Query("number"), c.Query("addr"), c.Query("types"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
 		return
@@ -48,7 +53,7 @@ func pageTransaction(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       hash path     string true "Transaction hash"
-// @Success     200  {object} model.Transaction
+// @Success     200  {object} service.TransactionRes
 // @Failure     400  {object} service.ErrRes
 // @Router      /transaction/{hash} [get]
 func getTransaction(c *gin.Context) {
@@ -68,7 +73,7 @@ func getTransaction(c *gin.Context) {
 // @Accept      json
 // @Produce     json
 // @Param       hash path     string true "Transaction hash"
-// @Success     200  {object} []model.EventLog
+// @Success     200  {object} []service.EventLogRes
 // @Failure     400  {object} service.ErrRes
 // @Router      /transaction_logs/{hash} [get]
 func getTransactionLogs(c *gin.Context) {
@@ -88,12 +93,13 @@ func getTransactionLogs(c *gin.Context) {
 // @Produce     json
 // @Param       page      query    string false "Page, default 1"
 // @Param       page_size query    string false "Page size, default 10"
+// @Param       cursor    query    string false "Cursor of the page, the next of the previous page, takes the place of page"
 // @Success     200       {object} service.InternalTxsRes
 // @Failure     400       {object} service.ErrRes
 // @Router      /transaction/internal/page [get]
 func pageInternalTransaction(c *gin.Context) {
 	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
-	data, err := service.GetInternalTransactions(page, size)
+	data, err := service.GetInternalTransactions(page, size, c.Query("cursor"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
 		return
@@ -118,6 +124,23 @@ func getInternalTransaction(c *gin.Context) {
 	c.JSON(http.StatusOK, data)
 }
 
+// @Tags        transaction
+// @Summary     query internal transaction call tree
+// @Description specifies the hash query the call tree of the transaction, the root is the transaction itself
+// @Produce     json
+// @Param       hash path     string true "tx hash"
+// @Success     200  {object} service.CallFrame
+// @Failure     400  {object} service.ErrRes
+// @Router      /transaction/internal/{hash}/tree [get]
+func getInternalTransactionTree(c *gin.Context) {
+	data, err := service.GetInternalTransactionTree(c.Param("hash"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, data)
+}
+
 // @Tags        transaction
 // @Summary     query erbie transaction list
 // @Description query erbie transaction list in reverse order
@@ -125,6 +148,7 @@ func getInternalTransaction(c *gin.Context) {
 // @Produce     json
 // @Param       page      query    string false "Page, default 1"
 // @Param       page_size query    string false "Page size, default 10"
+// @Param       cursor    query    string false "Cursor of the page, the next of the previous page, takes the place of page"
 // @Param       number    query    string false "Block number, if empty, query all"
 // @Param       epoch     query    string false "Specify the period id"
 // @Param       address   query    string false "nft or snft address, if empty, query all"
@@ -135,7 +159,7 @@ func getInternalTransaction(c *gin.Context) {
 // @Router      /transaction/erbie/page [get]
 func pageErbieTransaction(c *gin.Context) {
 	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
-	data, err := service.FetchErbieTxs(page, size, c.Query("number"), c.Query("address"), c.Query("epoch"), c.Query("account"), c.Query("types"))
+	data, err := service.FetchErbieTxs(page, size, c.Query("cursor"), c.Query("number"), c.Query("address"), c.Query("epoch"), c.Query("account"), c.Query("types"))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
 		return
@@ -159,3 +183,99 @@ func getErbieTransaction(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, data)
 }
+
+// @Tags        transaction
+// @Summary     query ERC20 transfer list
+// @Description query the ERC20 transfer events in reverse order
+// @Accept      json
+// @Produce     json
+// @Param       page       query    string false "Page, default 1"
+// @Param       page_size  query    string false "Page size, default 10"
+// @Param       cursor     query    string false "Cursor of the page, the next of the previous page, takes the place of page"
+// @Param       address    query    string false "Token contract address, if empty, query all"
+// @Param       from       query    string false "Sender address, if empty, query all"
+// @Param       to         query    string false "Receiver address, if empty, query all"
+// @Param       addr       query    string false "Sender or receiver address, if empty, query all"
+// @Param       tx_hash    query    string false "Transaction hash, if empty, query all"
+// @Param       from_block query    string false "First block number, if empty, from the genesis"
+// @Param       to_block   query    string false "Last block number, if empty, to the head"
+// @Success     200        {object} service.ERC20TransfersRes
+// @Failure     400        {object} service.ErrRes
+// @Router      /transaction/erc20/page [get]
+func pageERC20Transfer(c *gin.Context) {
+	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
+	data, err := service.FetchERC20Transfers(page, size, c.Query("cursor"), transferFilter(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, data)
+}
+
+// @Tags        transaction
+// @Summary     query ERC721 transfer list
+// @Description query the ERC721 transfer events in reverse order
+// @Accept      json
+// @Produce     json
+// @Param       page       query    string false "Page, default 1"
+// @Param       page_size  query    string false "Page size, default 10"
+// @Param       cursor     query    string false "Cursor of the page, the next of the previous page, takes the place of page"
+// @Param       address    query    string false "Token contract address, if empty, query all"
+// @Param       from       query    string false "Sender address, if empty, query all"
+// @Param       to         query    string false "Receiver address, if empty, query all"
+// @Param       addr       query    string false "Sender or receiver address, if empty, query all"
+// @Param       tx_hash    query    string false "Transaction hash, if empty, query all"
+// @Param       from_block query    string false "First block number, if empty, from the genesis"
+// @Param       to_block   query    string false "Last block number, if empty, to the head"
+// @Success     200        {object} service.ERC721TransfersRes
+// @Failure     400        {object} service.ErrRes
+// @Router      /transaction/erc721/page [get]
+func pageERC721Transfer(c *gin.Context) {
+	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
+	data, err := service.FetchERC721Transfers(page, size, c.Query("cursor"), transferFilter(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, data)
+}
+
+// @Tags        transaction
+// @Summary     query ERC1155 transfer list
+// @Description query the ERC1155 transfer events in reverse order
+// @Accept      json
+// @Produce     json
+// @Param       page       query    string false "Page, default 1"
+// @Param       page_size  query    string false "Page size, default 10"
+// @Param       cursor     query    string false "Cursor of the page, the next of the previous page, takes the place of page"
+// @Param       address    query    string false "Token contract address, if empty, query all"
+// @Param       from       query    string false "Sender address, if empty, query all"
+// @Param       to         query    string false "Receiver address, if empty, query all"
+// @Param       addr       query    string false "Sender or receiver address, if empty, query all"
+// @Param       tx_hash    query    string false "Transaction hash, if empty, query all"
+// @Param       from_block query    string false "First block number, if empty, from the genesis"
+// @Param       to_block   query    string false "Last block number, if empty, to the head"
+// @Success     200        {object} service.ERC1155TransfersRes
+// @Failure     400        {object} service.ErrRes
+// @Router      /transaction/erc1155/page [get]
+func pageERC1155Transfer(c *gin.Context) {
+	page, size := utils.ParsePagination(c.Query("page"), c.Query("page_size"))
+	data, err := service.FetchERC1155Transfers(page, size, c.Query("cursor"), transferFilter(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, service.ErrRes{ErrStr: err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, data)
+}
+
+func transferFilter(c *gin.Context) service.TransferFilter {
+	return service.TransferFilter{
+		Address:   c.Query("address"),
+		From:      c.Query("from"),
+		To:        c.Query("to"),
+		Addr:      c.Query("addr"),
+		TxHash:    c.Query("tx_hash"),
+		FromBlock: c.Query("from_block"),
+		ToBlock:   c.Query("to_block"),
+	}
+}
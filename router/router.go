@@ -1,18 +1,34 @@
 package router
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
 	"github.com/gin-gonic/gin"
 	"github.com/swaggo/files"
 	"github.com/swaggo/gin-swagger"
+	"server/conf"
 	_ "server/docs"
 	"server/middleware"
 	"server/router/api"
 )
 
-func Run(addr string) error {
+// Run serves the API until the context is cancelled, then shuts the server down, waiting at most the timeout
+// for the active requests
+func Run(ctx context.Context, addr string, timeout time.Duration) error {
 	r := gin.New()
+	// the client IP is read from X-Forwarded-For only behind the trusted proxies
+	if err := r.SetTrustedProxies(conf.TrustedProxies); err != nil {
+		return err
+	}
 	// Allow cross-domain access, and those with nginx and other proxies can be closed
 	r.Use(middleware.Cors())
+	// The API key of the X-API-Key header picks the role of the request, the admin routes require the admin role
+	r.Use(middleware.Auth(conf.AdminKey))
+	// Each API key or client IP has a token bucket per route group and a daily quota of its role
+	r.Use(middleware.RateLimit(conf.RateLimits, conf.DailyQuotas))
 	// Set up accessible routes
 	api.Extra(r)
 	api.Block(r)
@@ -26,6 +42,21 @@ func Run(addr string) error {
 	api.Ranking(r)
 	api.Chart(r)
 	api.Validator(r)
+	api.Token(r)
+	api.Contract(r)
+	api.Search(r)
+	api.Reorg(r)
+	api.Admin(r)
 	r.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
-	return r.Run(addr)
+	srv, shutdown := &http.Server{Addr: addr, Handler: r}, make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		shutdown <- srv.Shutdown(ctx)
+	}()
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return <-shutdown
 }
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"server/common/model"
+	"server/common/types"
+	"server/common/utils"
+)
+
+// contracts caches the ABIs and the proxy implementations of the decoded contracts
+var contracts = newContractCache(10000, time.Minute)
+
+// SaveABI stores the ABI of the contract, the custom errors it declares are decoded in the revert reasons from now on
+func SaveABI(addr string, name *string, data []byte) (res model.ContractABI, err error) {
+	address := types.Address(strings.ToLower(addr))
+	if len(address) != 42 || !strings.HasPrefix(string(address), "0x") {
+		return res, errors.New("invalid contract address")
+	}
+	abi, err := utils.ParseABI(data)
+	if err != nil {
+		return
+	}
+	res = model.ContractABI{Address: address, Name: name, ABI: data, Timestamp: types.Long(time.Now().Unix())}
+	if err = DB.Clauses(clause.OnConflict{UpdateAll: true}).Create(&res).Error; err != nil {
+		return
+	}
+	contracts.remove(address)
+	registerErrors(abi)
+	return
+}
+
+func GetABI(addr string) (res model.ContractABI, err error) {
+	err = DB.Where("address=?", strings.ToLower(addr)).Take(&res).Error
+	return
+}
+
+// loadABI returns the parsed ABI of the contract, the ABI of the current implementation for a proxy without its own ABI,
+// nil when it has none
+func loadABI(address types.Address) utils.ABI {
+	contract := loadContract(types.Address(strings.ToLower(string(address))))
+	if contract.abi == nil && contract.implementation != nil {
+		return loadContract(*contract.implementation).abi
+	}
+	return contract.abi
+}
+
+// loadContract returns the uploaded ABI and the current implementation of the contract, from the cache when they
+// are recently loaded. They are not cached when the database fails
+func loadContract(address types.Address) *cachedContract {
+	now := time.Now()
+	if contract, ok := contracts.get(address, now); ok {
+		return contract
+	}
+	contract := &cachedContract{address: address}
+	abi, err := storedABI(address)
+	if err != nil {
+		return contract
+	}
+	implementation, err := implementationOf(address)
+	if err != nil {
+		return contract
+	}
+	contract.abi, contract.implementation = abi, implementation
+	contracts.put(contract, now)
+	return contract
+}
+
+// storedABI returns the uploaded ABI of the contract, nil when it has none
+func storedABI(address types.Address) (abi utils.ABI, err error) {
+	var stored []*model.ContractABI
+	if err = DB.Where("address=?", address).Limit(1).Find(&stored).Error; err != nil || len(stored) == 0 {
+		return
+	}
+	// the stored ABIs were parsed when they were uploaded
+	abi, _ = utils.ParseABI(stored[0].ABI)
+	return
+}
+
+func registerErrors(abi utils.ABI) {
+	for _, entry := range abi {
+		if entry.Type == "error" {
+			utils.RegisterError(entry.Signature())
+		}
+	}
+}
+
+// initABIs makes the custom errors of the stored ABIs known to the revert decoding
+func initABIs(db *gorm.DB) (err error) {
+	var stored []*model.ContractABI
+	if err = db.Find(&stored).Error; err != nil {
+		return
+	}
+	for _, contract := range stored {
+		if abi, err := utils.ParseABI(contract.ABI); err == nil {
+			registerErrors(abi)
+		}
+	}
+	return
+}
+
+// decodeTransaction decodes the input and the revert data of the transaction with the ABI of the called contract
+func decodeTransaction(tx *model.Transaction) (input, revert *utils.ABIDecoded) {
+	if tx.To == nil {
+		return
+	}
+	abi := loadABI(*tx.To)
+	if abi == nil {
+		return
+	}
+	input, _ = abi.DecodeInput(tx.Input)
+	if tx.RevertData != "" {
+		revert, _ = abi.DecodeError(tx.RevertData)
+	}
+	return
+}
+
+// decodeLog decodes the event log with the ABI of the emitting contract
+func decodeLog(log *model.EventLog) *utils.ABIDecoded {
+	abi := loadABI(log.Address)
+	if abi == nil {
+		return nil
+	}
+	decoded, _ := abi.DecodeLog(log.Topics, log.Data)
+	return decoded
+}
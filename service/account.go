@@ -47,9 +47,9 @@ func GetAccount(addr string) (res AccountRes, err error) {
 	db := DB.Model(model.Account{}).Joins("LEFT JOIN validators ON validators.address=accounts.address")
 	db = db.Joins("LEFT JOIN stakers ON stakers.address=accounts.address")
 	db = db.Joins("LEFT JOIN creators ON creators.address=accounts.address")
-	s := "accounts.*, creators.last_number, IFNULL(creators.reward,'0') AS reward, (SELECT COUNT(*) FROM nfts WHERE owner=accounts.address) AS nft_count"
-	s += ", validators.weight AS weight, IFNULL(validators.amount,'0') AS validator_amount, validators.reward_count AS reward_coin_count"
-	s += ", IFNULL(validators.reward,'0') AS validator_reward, IFNULL(stakers.amount,'0') AS staker_amount, IFNULL(profit,'0') AS profit, stakers.reward_count AS reward_snft_count"
+	s := "accounts.*, creators.last_number, COALESCE(creators.reward,'0') AS reward, (SELECT COUNT(*) FROM nfts WHERE owner=accounts.address) AS nft_count"
+	s += ", validators.weight AS weight, COALESCE(validators.amount,'0') AS validator_amount, validators.reward_count AS reward_coin_count"
+	s += ", COALESCE(validators.reward,'0') AS validator_reward, COALESCE(stakers.amount,'0') AS staker_amount, COALESCE(profit,'0') AS profit, stakers.reward_count AS reward_snft_count"
 	err = db.Select(s).Where("accounts.address=?", addr).Scan(&res).Error
 	return
 }
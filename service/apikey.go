@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"server/common/model"
+	"server/common/types"
+)
+
+// ErrInvalidKey the API key is unknown or revoked
+var ErrInvalidKey = errors.New("invalid API key")
+
+// keyCacheTTL how long a valid key is trusted without looking it up again, a key revoked by another process
+// (e.g. the CLI) is refused after at most this time
+const keyCacheTTL = 30 * time.Second
+
+type cachedKey struct {
+	key     *model.APIKey
+	expires time.Time
+}
+
+// apiKeys caches the valid keys by the hash of the key
+var apiKeys sync.Map
+
+type usageKey struct {
+	id  int64
+	day string
+}
+
+// keyUsage counts the requests of the keys until they are written by FlushKeyUsage
+var keyUsage = struct {
+	sync.Mutex
+	requests map[usageKey]int64
+	lastUsed map[int64]types.Long
+}{requests: make(map[usageKey]int64), lastUsed: make(map[int64]types.Long)}
+
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// IssueAPIKey creates an API key of the role, the key itself is returned only here
+func IssueAPIKey(name, role string) (key string, res model.APIKey, err error) {
+	valid := false
+	for _, r := range model.Roles {
+		valid = valid || r == role
+	}
+	if !valid {
+		return "", res, fmt.Errorf("unknown role %q, one of %v", role, model.Roles)
+	}
+	buf := make([]byte, 24)
+	if _, err = rand.Read(buf); err != nil {
+		return
+	}
+	key = "wh_" + hex.EncodeToString(buf)
+	res = model.APIKey{Name: name, Prefix: key[:8], Hash: hashKey(key), Role: role, Timestamp: types.Long(time.Now().Unix())}
+	err = DB.Create(&res).Error
+	return
+}
+
+// RevokeAPIKey refuses the key from now on
+func RevokeAPIKey(id int64) error {
+	db := DB.Model(&model.APIKey{}).Where("id=? AND revoked_at IS NULL", id).Update("revoked_at", time.Now().Unix())
+	if db.Error != nil {
+		return db.Error
+	}
+	if db.RowsAffected == 0 {
+		return fmt.Errorf("no valid API key %v", id)
+	}
+	apiKeys.Range(func(hash, cached any) bool {
+		if cached.(cachedKey).key.ID == id {
+			apiKeys.Delete(hash)
+		}
+		return true
+	})
+	return nil
+}
+
+func FetchAPIKeys() (res []model.APIKey, err error) {
+	err = DB.Order("id").Find(&res).Error
+	return
+}
+
+// CachedAPIKey returns the valid API key when it is cached, nil when Authenticate has to look it up
+func CachedAPIKey(key string) *model.APIKey {
+	if cached, ok := apiKeys.Load(hashKey(key)); ok && time.Now().Before(cached.(cachedKey).expires) {
+		return cached.(cachedKey).key
+	}
+	return nil
+}
+
+// Authenticate returns the valid API key, ErrInvalidKey when it is unknown or revoked
+func Authenticate(key string) (*model.APIKey, error) {
+	if cached := CachedAPIKey(key); cached != nil {
+		return cached, nil
+	}
+	hash := hashKey(key)
+	var res model.APIKey
+	if err := DB.Where("hash=? AND revoked_at IS NULL", hash).Limit(1).Find(&res).Error; err != nil {
+		return nil, err
+	}
+	if res.ID == 0 {
+		apiKeys.Delete(hash)
+		return nil, ErrInvalidKey
+	}
+	apiKeys.Store(hash, cachedKey{key: &res, expires: time.Now().Add(keyCacheTTL)})
+	return &res, nil
+}
+
+// RecordKeyUsage counts a request made with the key
+func RecordKeyUsage(id int64) {
+	now := time.Now()
+	keyUsage.Lock()
+	defer keyUsage.Unlock()
+	keyUsage.requests[usageKey{id, now.Format("2006-01-02")}]++
+	keyUsage.lastUsed[id] = types.Long(now.Unix())
+}
+
+// KeyUsage returns the number of written requests of the key in the day, YYYY-MM-DD
+func KeyUsage(id int64, day string) (requests int64, err error) {
+	err = DB.Model(&model.APIKeyUsage{}).Where("key_id=? AND day=?", id, day).Select("requests").Scan(&requests).Error
+	return
+}
+
+// FlushKeyUsage writes the requests counted since the last flush
+func FlushKeyUsage() error {
+	keyUsage.Lock()
+	requests, lastUsed := keyUsage.requests, keyUsage.lastUsed
+	keyUsage.requests, keyUsage.lastUsed = make(map[usageKey]int64), make(map[int64]types.Long)
+	keyUsage.Unlock()
+	if len(requests) == 0 {
+		return nil
+	}
+	return DB.Transaction(func(db *gorm.DB) (err error) {
+		usages, totals := make([]*model.APIKeyUsage, 0, len(requests)), make(map[int64]int64)
+		for key, count := range requests {
+			usages = append(usages, &model.APIKeyUsage{KeyID: key.id, Day: key.day, Requests: count})
+			totals[key.id] += count
+		}
+		err = db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "key_id"}, {Name: "day"}},
+			DoUpdates: clause.Assignments(map[string]any{"requests": gorm.Expr("api_key_usages.`requests`+" + dialect.excluded("requests"))}),
+		}).Create(usages).Error
+		if err != nil {
+			return
+		}
+		for id, count := range totals {
+			err = db.Model(&model.APIKey{}).Where("id=?", id).
+				Updates(map[string]any{"requests": gorm.Expr("requests+?", count), "last_used": lastUsed[id]}).Error
+			if err != nil {
+				return
+			}
+		}
+		return
+	})
+}
+
+// WatchKeyUsage writes the key usage every interval until the context is cancelled, then writes the rest
+func WatchKeyUsage(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := FlushKeyUsage(); err != nil {
+				log.Printf("Failed to write the API key usage: %v\n", err)
+			}
+		case <-ctx.Done():
+			if err := FlushKeyUsage(); err != nil {
+				log.Printf("Failed to write the API key usage: %v\n", err)
+			}
+			return
+		}
+	}
+}
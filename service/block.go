@@ -6,11 +6,12 @@ import (
 
 // BlocksRes block paging return parameters
 type BlocksRes struct {
-	Total  int64         `json:"total"`  //The total number of blocks
-	Blocks []model.Block `json:"blocks"` //block list
+	Total  int64         `json:"total"`          //The total number of blocks, not counted on the filtered pages after a cursor
+	Blocks []model.Block `json:"blocks"`         //block list
+	Next   string        `json:"next,omitempty"` //cursor of the next page, empty on the last page
 }
 
-func FetchBlocks(page, size int, filter string) (res BlocksRes, err error) {
+func FetchBlocks(page, size int, cursor, filter string) (res BlocksRes, err error) {
 	db := DB.Model(&model.Block{})
 	if filter == "1" {
 		db = db.Where("number!=0 AND `miner` = '0x0000000000000000000000000000000000000000'")
@@ -18,20 +19,35 @@ func FetchBlocks(page, size int, filter string) (res BlocksRes, err error) {
 		db = db.Where("`proof` != '[]'")
 	}
 	if filter != "" {
-		if err = db.Count(&res.Total).Error; err != nil {
-			return
+		// the pages after a cursor are not counted again
+		if cursor == "" {
+			if err = db.Count(&res.Total).Error; err != nil {
+				return
+			}
 		}
 	} else {
 		// use stats to speed up queries
 		res.Total = stats.TotalBlock
 	}
 
-	err = db.Order("number DESC").Offset((page - 1) * size).Limit(size).Find(&res.Blocks).Error
+	if db, err = paginate(db, page, size, cursor, "number"); err != nil {
+		return
+	}
+	if err = db.Find(&res.Blocks).Error; err != nil {
+		return
+	}
+	for i := range res.Blocks {
+		res.Blocks[i].Confirmations, res.Blocks[i].Finalized = confirm(res.Blocks[i].Number)
+	}
+	if n := len(res.Blocks); n > 0 {
+		res.Next = nextCursor(size, n, res.Blocks[n-1].Number)
+	}
 	return
 }
 
 func GetBlock(number string) (b model.Block, err error) {
 	err = DB.Where("number=?", number).First(&b).Error
+	b.Confirmations, b.Finalized = confirm(b.Number)
 	return
 }
 
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"server/common/types"
+	"server/common/utils"
+)
+
+// cachedContract the decoding data of a contract: its uploaded ABI and its current implementation when it is a proxy,
+// nil when it has none
+type cachedContract struct {
+	address        types.Address
+	abi            utils.ABI
+	implementation *types.Address
+	expires        time.Time
+}
+
+// contractCache caches the decoding data of the recently used contracts. The least recently used ones are evicted over
+// the size, and the entries expire so that the proxy upgrades written by the indexer in another process are seen
+type contractCache struct {
+	sync.Mutex
+	size    int
+	ttl     time.Duration
+	recent  *list.List // *cachedContract, the most recently used first
+	entries map[types.Address]*list.Element
+}
+
+func newContractCache(size int, ttl time.Duration) *contractCache {
+	return &contractCache{size: size, ttl: ttl, recent: list.New(), entries: make(map[types.Address]*list.Element)}
+}
+
+// get returns the cached contract that has not expired
+func (c *contractCache) get(address types.Address, now time.Time) (*cachedContract, bool) {
+	c.Lock()
+	defer c.Unlock()
+	element, ok := c.entries[address]
+	if !ok {
+		return nil, false
+	}
+	contract := element.Value.(*cachedContract)
+	if now.After(contract.expires) {
+		c.recent.Remove(element)
+		delete(c.entries, address)
+		return nil, false
+	}
+	c.recent.MoveToFront(element)
+	return contract, true
+}
+
+// put caches the contract until the ttl from now, evicting the least recently used one over the size
+func (c *contractCache) put(contract *cachedContract, now time.Time) {
+	c.Lock()
+	defer c.Unlock()
+	contract.expires = now.Add(c.ttl)
+	if element, ok := c.entries[contract.address]; ok {
+		element.Value = contract
+		c.recent.MoveToFront(element)
+		return
+	}
+	c.entries[contract.address] = c.recent.PushFront(contract)
+	if c.recent.Len() > c.size {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cachedContract).address)
+	}
+}
+
+// remove drops the cached contract, it is loaded again on the next use
+func (c *contractCache) remove(address types.Address) {
+	c.Lock()
+	defer c.Unlock()
+	if element, ok := c.entries[address]; ok {
+		c.recent.Remove(element)
+		delete(c.entries, address)
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"server/common/model"
+	"server/common/types"
+)
+
+func TestContractCache(t *testing.T) {
+	cache, now := newContractCache(2, time.Minute), time.Now()
+	for _, address := range []types.Address{"0x01", "0x02", "0x03"} {
+		cache.put(&cachedContract{address: address}, now)
+		cache.get("0x01", now)
+	}
+	// 0x02 is the least recently used
+	if _, ok := cache.get("0x02", now); ok {
+		t.Fatal("least recently used contract kept")
+	}
+	if _, ok := cache.get("0x01", now); !ok {
+		t.Fatal("recently used contract evicted")
+	}
+	if _, ok := cache.get("0x03", now.Add(2*time.Minute)); ok {
+		t.Fatal("expired contract returned")
+	}
+	cache.remove("0x01")
+	if _, ok := cache.get("0x01", now); ok || cache.recent.Len() != 0 || len(cache.entries) != 0 {
+		t.Fatal("removed contract kept")
+	}
+}
+
+func TestLoadABI(t *testing.T) {
+	openTestDB(t)
+	defer func(cache *contractCache) { contracts = cache }(contracts)
+	contracts = newContractCache(10, time.Minute)
+	proxy, implementation := types.Address("0x00000000000000000000000000000000000000b1"), types.Address("0x00000000000000000000000000000000000000b2")
+	if err := DB.Create(&model.ProxyImplementation{Address: proxy, Kind: "eip1967", Implementation: implementation}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if abi := loadABI(proxy); abi != nil {
+		t.Fatal("ABI without upload", abi)
+	}
+	// the upload replaces the cached contracts without ABI
+	if _, err := SaveABI(string(implementation), nil, []byte(`[{"type":"function","name":"f","inputs":[]}]`)); err != nil {
+		t.Fatal(err)
+	}
+	if abi := loadABI(proxy); len(abi) != 1 || abi[0].Name != "f" {
+		t.Fatal("implementation ABI", abi)
+	}
+	if _, err := SaveABI(string(proxy), nil, []byte(`[{"type":"function","name":"g","inputs":[]}]`)); err != nil {
+		t.Fatal(err)
+	}
+	if abi := loadABI(proxy); len(abi) != 1 || abi[0].Name != "g" {
+		t.Fatal("proxy ABI", abi)
+	}
+}
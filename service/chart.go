@@ -2,6 +2,7 @@ package service
 
 import (
 	"server/common/model"
+	"server/common/types"
 	"server/common/utils"
 )
 
@@ -11,8 +12,8 @@ type LineChartRes struct {
 		TotalTransaction uint64 `json:"txCount"`
 	} `json:"blocks"`
 	Txs []*struct {
-		Hash     string `json:"hash"`
-		GasPrice uint64 `json:"gasPrice"`
+		Hash     string       `json:"hash"`
+		GasPrice types.BigInt `json:"gasPrice"`
 	} `json:"txs"`
 	Stakers []*struct {
 		Index uint64 `json:"index"`
@@ -31,7 +32,7 @@ func LineChart(limit int) (res LineChartRes, err error) {
 		return
 	}
 	start, stop := utils.LastTimeRange(int64(limit))
-	err = DB.Table("(?) A", DB.Model(&model.Staker{}).Select("(timestamp-?) DIV 86400 AS `index`, FROM_UNIXTIME(timestamp,'%Y-%m-%d') AS `day`", start).
+	err = DB.Table("(?) A", DB.Model(&model.Staker{}).Select(dialect.div("timestamp-?", "86400")+" AS `index`, "+dialect.day("timestamp")+" AS `day`", start).
 		Where("timestamp>=? AND timestamp<?", start, stop)).Group("`index`, `day`").Select("*, COUNT(*) AS num").Scan(&res.Stakers).Error
 	return
 }
@@ -43,7 +44,7 @@ type TxChartRes struct {
 
 func TxChart() (res []*TxChartRes, err error) {
 	start, stop := utils.LastTimeRange(int64(1))
-	err = DB.Table("(?) A", DB.Model(&model.Block{}).Select("(timestamp-?) DIV 3600 AS `hour`,`total_transaction`", start).
+	err = DB.Table("(?) A", DB.Model(&model.Block{}).Select(dialect.div("timestamp-?", "3600")+" AS `hour`,`total_transaction`", start).
 		Where("timestamp>=? AND timestamp<?", start, stop)).Group("`hour`").Order("`hour`").Select("`hour`, SUM(total_transaction) AS num").Scan(&res).Error
 	return
 }
@@ -55,7 +56,7 @@ type NFTChartRes struct {
 
 func NFTChart() (res []*NFTChartRes, err error) {
 	start, stop := utils.LastTimeRange(int64(1))
-	err = DB.Table("(?) A", DB.Model(&model.NFT{}).Select("(timestamp-?) DIV 3600 AS `hour`,`address`", start).
+	err = DB.Table("(?) A", DB.Model(&model.NFT{}).Select(dialect.div("timestamp-?", "3600")+" AS `hour`,`address`", start).
 		Where("timestamp>=? AND timestamp<?", start, stop)).Group("`hour`").Order("`hour`").Select("`hour`, COUNT(address) AS num").Scan(&res).Error
 	return
 }
@@ -67,7 +68,7 @@ type AccountChartRes struct {
 
 func AccountChart() (res []*AccountChartRes, err error) {
 	start, stop := utils.LastTimeRange2(int64(1))
-	err = DB.Table("(?) A", DB.Model(&model.Account{}).Select("(timestamp-?) DIV 3600 AS `hour`,`address`", start).
+	err = DB.Table("(?) A", DB.Model(&model.Account{}).Select(dialect.div("timestamp-?", "3600")+" AS `hour`,`address`", start).
 		Where("timestamp>=? AND timestamp<?", start, stop)).Group("`hour`").Order("`hour`").Select("`hour`, COUNT(address) AS num").Scan(&res).Error
 	return
 }
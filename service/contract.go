@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"server/common/types"
+	"server/common/utils"
+	"server/conf"
+	"server/node"
+)
+
+// chain is the client of the chain nodes used by the queries that call the contracts, dialed on first use
+var chain struct {
+	sync.Mutex
+	client *node.Client
+}
+
+func chainClient() (*node.Client, error) {
+	chain.Lock()
+	defer chain.Unlock()
+	if chain.client == nil {
+		client, err := node.Dial(conf.ChainUrl)
+		if err != nil {
+			return nil, err
+		}
+		chain.client = client
+	}
+	return chain.client, nil
+}
+
+// ReadContractReq call of a view function
+type ReadContractReq struct {
+	Function string          `json:"function"`                                 //function name, or text signature for the overloaded functions, e.g. balanceOf(address)
+	Args     []any           `json:"args"`                                     //arguments: numbers as JSON numbers or decimal or hex strings, addresses and bytes as hex strings, arrays as lists, tuples as lists or objects
+	Block    string          `json:"block,omitempty"`                          //block number, default latest
+	ABI      json.RawMessage `json:"abi,omitempty" swaggertype:"array,object"` //contract ABI, default the uploaded ABI of the contract
+}
+
+// ReadContractRes return values of a view function
+type ReadContractRes struct {
+	Signature string            `json:"signature"`         //signature of the called function
+	Block     string            `json:"block"`             //block number the function is called at
+	Outputs   []*utils.ABIValue `json:"outputs,omitempty"` //decoded return values, empty when the function outputs are not known
+	Raw       types.Bytes       `json:"raw"`               //raw return data
+}
+
+// ReadContract calls the view function of the contract with eth_call, the call is encoded and the outputs decoded with
+// the ABI of the request or the uploaded ABI, a text signature without ABI returns the raw data only
+func ReadContract(ctx context.Context, addr string, req ReadContractReq) (res ReadContractRes, err error) {
+	address := types.Address(strings.ToLower(addr))
+	if len(address) != 42 || !strings.HasPrefix(string(address), "0x") {
+		return res, errors.New("invalid contract address")
+	}
+	abi := loadABI(address)
+	if len(req.ABI) > 0 {
+		if abi, err = utils.ParseABI(req.ABI); err != nil {
+			return
+		}
+	}
+	var function *utils.ABIEntry
+	if abi != nil {
+		function, err = abi.Function(req.Function, len(req.Args))
+	} else if strings.Contains(req.Function, "(") {
+		function, err = utils.SignatureEntry("function", req.Function)
+	} else {
+		err = errors.New("the contract has no ABI, give the ABI or the function signature")
+	}
+	if err != nil {
+		return
+	}
+	if len(req.Args) != len(function.Inputs) {
+		return res, errors.New(function.Signature() + " takes " + strconv.Itoa(len(function.Inputs)) + " arguments")
+	}
+	input, err := utils.EncodeABIArgs(function.Inputs, req.Args)
+	if err != nil {
+		return res, errors.New(function.Signature() + ": " + err.Error())
+	}
+	var block any = "latest"
+	res.Signature, res.Block = function.Signature(), "latest"
+	if req.Block != "" && req.Block != "latest" {
+		var number types.Long
+		if err = number.UnmarshalText([]byte(req.Block)); err != nil {
+			return res, errors.New("block error")
+		}
+		block, res.Block = number.Hex(), strconv.FormatInt(int64(number), 10)
+	}
+	client, err := chainClient()
+	if err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if res.Raw, err = client.CallContract(ctx, address, function.Selector()+hex.EncodeToString(input), block); err != nil {
+		return
+	}
+	if len(function.Outputs) > 0 {
+		raw, _ := hex.DecodeString(strings.TrimPrefix(string(res.Raw), "0x"))
+		res.Outputs, err = utils.DecodeABIArgs(function.Outputs, raw)
+	}
+	return
+}
@@ -30,6 +30,6 @@ func TopCreators(size int) (res []model.Creator, err error) {
 	if size <= 0 {
 		size = 10
 	}
-	err = DB.Order("profit+reward DESC").Limit(size).Find(&res).Error
+	err = DB.Order(dialect.add("profit", "reward") + " DESC").Limit(size).Find(&res).Error
 	return
 }
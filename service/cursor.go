@@ -0,0 +1,40 @@
+package service
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"server/common/utils"
+)
+
+// paginate orders the list by the key columns descending and selects the page after the cursor when it is given,
+// otherwise the page by offset. The cursor of the next page is encoded from the keys of the last row with utils.EncodeCursor
+func paginate(db *gorm.DB, page, size int, cursor string, keys ...string) (*gorm.DB, error) {
+	db = db.Order(strings.Join(keys, " DESC, ") + " DESC").Limit(size)
+	if cursor == "" {
+		return db.Offset((page - 1) * size), nil
+	}
+	values, err := utils.DecodeCursor(cursor, len(keys))
+	if err != nil {
+		return nil, err
+	}
+	// (k1, k2, ...) < (v1, v2, ...) expanded, the row comparison does not use the indexes everywhere
+	conditions, args := make([]string, len(keys)), make([]any, 0, len(keys)*(len(keys)+1)/2)
+	for i := range keys {
+		terms := make([]string, 0, i+1)
+		for j := 0; j < i; j++ {
+			terms, args = append(terms, keys[j]+"=?"), append(args, values[j])
+		}
+		terms, args = append(terms, keys[i]+"<?"), append(args, values[i])
+		conditions[i] = "(" + strings.Join(terms, " AND ") + ")"
+	}
+	return db.Where(strings.Join(conditions, " OR "), args...), nil
+}
+
+// nextCursor returns the cursor after the last row of a full page, empty after the last page
+func nextCursor(size, count int, keys ...any) string {
+	if count < size {
+		return ""
+	}
+	return utils.EncodeCursor(keys...)
+}
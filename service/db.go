@@ -1,28 +1,32 @@
 package service
 
 import (
-	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
 	"server/common/model"
-	. "server/conf"
 )
 
 var DB *gorm.DB
 
-func init() {
-	var err error
-	DB, err = gorm.Open(mysql.Open(MysqlDsn+"?charset=utf8mb4&parseTime=True&loc=Local"), &gorm.Config{SkipDefaultTransaction: true})
-	if err != nil {
-		panic(err)
+// Open opens the database of the DSN, synchronizes the table structure and fills the data added by the upgrades,
+// it is called once before the database is used
+func Open(dsn string) (err error) {
+	if DB, err = openDB(dsn, &gorm.Config{SkipDefaultTransaction: true}); err != nil {
+		return
 	}
-	// Synchronize the table structure to the database, compare the structure in the database and the code, and perform DDL operations
-	if err = model.Migrate(DB); err != nil {
-		panic(err)
-	}
-	if err = initStats(DB); err != nil {
-		panic(err)
-	}
-	if err = initValidator(DB); err != nil {
-		panic(err)
+	// Synchronize the table structure to the database, then fill what the rows stored by the older versions lack
+	for _, init := range []func(*gorm.DB) error{
+		model.Migrate,
+		initTransfers,
+		initInternalTxs,
+		initBalances,
+		initInventory,
+		initABIs,
+		initSignatures,
+		initStats,
+	} {
+		if err = init(DB); err != nil {
+			return
+		}
 	}
+	return
 }
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+
+	"gorm.io/gorm"
+	"server/common/model"
+)
+
+// openTestDB opens an empty SQLite database as the DB of the test
+func openTestDB(t *testing.T) {
+	db, err := openDB("sqlite:"+filepath.Join(t.TempDir(), "test.db"), &gorm.Config{SkipDefaultTransaction: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = model.Migrate(db); err != nil {
+		t.Fatal(err)
+	}
+	DB = db
+	t.Cleanup(func() {
+		if conn, err := db.DB(); err == nil {
+			conn.Close()
+		}
+		DB = nil
+	})
+}
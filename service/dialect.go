@@ -0,0 +1,278 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/mattn/go-sqlite3"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+	"gorm.io/gorm/schema"
+)
+
+// sqlDialect writes the SQL that differs between MySQL, PostgreSQL and SQLite, the rest of the raw SQL sticks to the
+// common subset. The identifiers may be quoted with backticks, they are turned into double quotes for PostgreSQL
+type sqlDialect string
+
+const (
+	dialectMySQL    sqlDialect = "mysql"
+	dialectPostgres sqlDialect = "postgres"
+	dialectSQLite   sqlDialect = "sqlite"
+)
+
+var dialect = dialectMySQL
+
+// openDB opens the database of the DSN, the scheme picks the driver: postgres:// or postgresql:// for PostgreSQL,
+// sqlite: for SQLite (e.g. sqlite:scan.db), mysql:// or none for MySQL (e.g. root:123456@tcp(127.0.0.1:3306)/scan)
+func openDB(dsn string, config *gorm.Config) (*gorm.DB, error) {
+	switch {
+	case strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://"):
+		conn, err := sql.Open("pgx", dsn)
+		if err != nil {
+			return nil, err
+		}
+		dialect = dialectPostgres
+		return gorm.Open(postgres.New(postgres.Config{Conn: quotePool{conn}}), config)
+	case strings.HasPrefix(dsn, "sqlite:"):
+		path := strings.TrimPrefix(strings.TrimPrefix(dsn, "sqlite:"), "//")
+		if !strings.Contains(path, "?") {
+			// let the queries read while the indexer writes
+			path += "?_journal_mode=WAL&_busy_timeout=10000&_foreign_keys=off"
+		}
+		dialect = dialectSQLite
+		// a statement takes at most 32766 variables
+		config.CreateBatchSize = 500
+		return gorm.Open(sqliteDialector{sqlite.Dialector{DriverName: sqliteDriver(), DSN: path}}, config)
+	default:
+		dsn = strings.TrimPrefix(dsn, "mysql://")
+		if !strings.Contains(dsn, "?") {
+			dsn += "?charset=utf8mb4&parseTime=True&loc=Local"
+		}
+		dialect = dialectMySQL
+		return gorm.Open(mysql.Open(dsn), config)
+	}
+}
+
+// excluded returns the value of the column in the row that conflicted with an upsert
+func (d sqlDialect) excluded(column string) string {
+	if d == dialectMySQL {
+		return "VALUES(`" + column + "`)"
+	}
+	return "excluded.`" + column + "`"
+}
+
+// upsert returns the clause that updates the columns of the row the INSERT ... SELECT conflicts with on the key
+func (d sqlDialect) upsert(key []string, columns ...string) string {
+	assignments := make([]string, len(columns))
+	for i, column := range columns {
+		assignments[i] = "`" + column + "`=" + d.excluded(column)
+	}
+	if d == dialectMySQL {
+		return " ON DUPLICATE KEY UPDATE " + strings.Join(assignments, ",")
+	}
+	return " ON CONFLICT (`" + strings.Join(key, "`,`") + "`) DO UPDATE SET " + strings.Join(assignments, ",")
+}
+
+// div returns the integer division of the integer expression
+func (d sqlDialect) div(expr, divisor string) string {
+	if d == dialectMySQL {
+		return "(" + expr + ") DIV " + divisor
+	}
+	return "(" + expr + ")/" + divisor
+}
+
+// day returns the local date (YYYY-MM-DD) of the unix time expression
+func (d sqlDialect) day(expr string) string {
+	switch d {
+	case dialectPostgres:
+		return "TO_CHAR(TO_TIMESTAMP(" + expr + "),'YYYY-MM-DD')"
+	case dialectSQLite:
+		return "STRFTIME('%Y-%m-%d'," + expr + ",'unixepoch','localtime')"
+	}
+	return "FROM_UNIXTIME(" + expr + ",'%Y-%m-%d')"
+}
+
+// add returns the sum of the two decimal expressions, which orders them by their value
+func (d sqlDialect) add(a, b string) string {
+	if d == dialectSQLite {
+		return "(decimal_add(" + a + "," + b + ") COLLATE decimal)"
+	}
+	return a + "+" + b
+}
+
+// like returns the case insensitive LIKE operator, the patterns escape the wildcards with '!'
+func (d sqlDialect) like() string {
+	if d == dialectPostgres {
+		return "ILIKE"
+	}
+	return "LIKE"
+}
+
+// requote turns the backtick quoted identifiers into double quoted ones, the string literals are left as they are
+func requote(query string) string {
+	if !strings.Contains(query, "`") {
+		return query
+	}
+	b, literal := []byte(query), false
+	for i, c := range b {
+		if c == '\'' {
+			literal = !literal
+		} else if c == '`' && !literal {
+			b[i] = '"'
+		}
+	}
+	return string(b)
+}
+
+// quotePool passes the SQL to PostgreSQL with the backticks requoted
+type quotePool struct {
+	*sql.DB
+}
+
+func (p quotePool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.DB.PrepareContext(ctx, requote(query))
+}
+
+func (p quotePool) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return p.DB.ExecContext(ctx, requote(query), args...)
+}
+
+func (p quotePool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return p.DB.QueryContext(ctx, requote(query), args...)
+}
+
+func (p quotePool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return p.DB.QueryRowContext(ctx, requote(query), args...)
+}
+
+func (p quotePool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx, err := p.DB.BeginTx(ctx, opts)
+	return quoteTx{tx}, err
+}
+
+func (p quotePool) GetDBConn() (*sql.DB, error) {
+	return p.DB, nil
+}
+
+type quoteTx struct {
+	*sql.Tx
+}
+
+func (t quoteTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.Tx.PrepareContext(ctx, requote(query))
+}
+
+func (t quoteTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.Tx.ExecContext(ctx, requote(query), args...)
+}
+
+func (t quoteTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.Tx.QueryContext(ctx, requote(query), args...)
+}
+
+func (t quoteTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return t.Tx.QueryRowContext(ctx, requote(query), args...)
+}
+
+var registerSQLite sync.Once
+
+// sqliteDriver registers the SQLite driver with the decimal collation and functions
+func sqliteDriver() string {
+	registerSQLite.Do(func() {
+		sql.Register("sqlite3_decimal", &sqlite3.SQLiteDriver{ConnectHook: func(conn *sqlite3.SQLiteConn) (err error) {
+			if err = conn.RegisterCollation("decimal", compareDecimal); err != nil {
+				return
+			}
+			return conn.RegisterFunc("decimal_add", addDecimal, true)
+		}})
+	})
+	return "sqlite3_decimal"
+}
+
+// sqliteDialector stores the DECIMAL columns as text ordered by the decimal collation, SQLite would keep only the
+// first 15 digits of the amounts in wei
+type sqliteDialector struct {
+	sqlite.Dialector
+}
+
+func (d sqliteDialector) DataTypeOf(field *schema.Field) string {
+	if strings.HasPrefix(strings.ToUpper(string(field.DataType)), "DECIMAL") {
+		return "TEXT COLLATE decimal"
+	}
+	return d.Dialector.DataTypeOf(field)
+}
+
+func (d sqliteDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return sqlite.Migrator{Migrator: migrator.Migrator{Config: migrator.Config{
+		DB:                          db,
+		Dialector:                   d,
+		CreateIndexAfterCreateTable: true,
+	}}}
+}
+
+// isNull reports whether the SQLite value is NULL, which the functions receive as a nil byte slice
+func isNull(value any) bool {
+	b, ok := value.([]byte)
+	return value == nil || ok && b == nil
+}
+
+// toDecimal reads the SQLite value as an integer, ok is false for NULL and the text that is no number
+func toDecimal(value any) (n *big.Int, ok bool) {
+	switch value := value.(type) {
+	case []byte:
+		if value != nil {
+			return new(big.Int).SetString(string(value), 10)
+		}
+	case int64:
+		return big.NewInt(value), true
+	case float64:
+		n, _ = big.NewFloat(value).Int(nil)
+		return n, true
+	case string:
+		return new(big.Int).SetString(value, 10)
+	}
+	return
+}
+
+// fromDecimal returns the integer as an SQLite value, the integers beyond 64 bits are returned as text
+func fromDecimal(n *big.Int) any {
+	if n.IsInt64() {
+		return n.Int64()
+	}
+	return n.String()
+}
+
+// compareDecimal orders the numbers by their value and before the other text
+func compareDecimal(a, b string) int {
+	x, okX := new(big.Int).SetString(a, 10)
+	y, okY := new(big.Int).SetString(b, 10)
+	switch {
+	case okX && okY:
+		return x.Cmp(y)
+	case okX:
+		return -1
+	case okY:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func addDecimal(a, b any) (any, error) {
+	x, okX := toDecimal(a)
+	y, okY := toDecimal(b)
+	if !okX || !okY {
+		if isNull(a) || isNull(b) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("decimal_add: %v+%v is not a number", a, b)
+	}
+	return fromDecimal(x.Add(x, y)), nil
+}
@@ -0,0 +1,141 @@
+package service
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"server/common/model"
+	"server/common/types"
+)
+
+// InventoryRes ERC721 token paging return parameters
+type InventoryRes struct {
+	Total  int64                `json:"total"`  //The total number of tokens
+	Tokens []*model.ERC721Token `json:"tokens"` //token list
+}
+
+// FetchInventory returns the tokens of the ERC721 collection that are not burnt, optionally of one owner
+func FetchInventory(addr, owner string, page, size int) (res InventoryRes, err error) {
+	db := DB.Model(&model.ERC721Token{}).Where("address=? AND owner<>?", addr, types.ZeroAddress)
+	if owner != "" {
+		db = db.Where("owner=?", owner)
+	}
+	if err = db.Count(&res.Total).Error; err != nil {
+		return
+	}
+	err = db.Order("LENGTH(token_id), token_id").Offset((page - 1) * size).Limit(size).Find(&res.Tokens).Error
+	return
+}
+
+// AccountNFT ERC721 token of an account with the collection properties
+type AccountNFT struct {
+	model.ERC721Token
+	Name   *string `json:"name,omitempty"`   //collection name
+	Symbol *string `json:"symbol,omitempty"` //collection symbol
+}
+
+// AccountNFTsRes account ERC721 token paging return parameters
+type AccountNFTsRes struct {
+	Total int64         `json:"total"` //The total number of tokens
+	NFTs  []*AccountNFT `json:"nfts"`  //token list, the last received first
+}
+
+func FetchAccountNFTs(addr string, page, size int) (res AccountNFTsRes, err error) {
+	db := DB.Model(&model.ERC721Token{}).Where("owner=?", addr)
+	if err = db.Count(&res.Total).Error; err != nil {
+		return
+	}
+	err = db.Joins("LEFT JOIN tokens ON tokens.address=erc721_tokens.address").
+		Select("erc721_tokens.*, tokens.name, tokens.symbol").
+		Order("erc721_tokens.block_number DESC, erc721_tokens.address, erc721_tokens.token_id").
+		Offset((page - 1) * size).Limit(size).Scan(&res.NFTs).Error
+	return
+}
+
+// ERC721TokenRes ERC721 token with its provenance
+type ERC721TokenRes struct {
+	AccountNFT
+	Transfers []*model.ERC721Transfer `json:"transfers"` //all the transfers of the token, the mint first
+}
+
+func GetERC721Token(addr, tokenId string) (res ERC721TokenRes, err error) {
+	err = DB.Model(&model.ERC721Token{}).Joins("LEFT JOIN tokens ON tokens.address=erc721_tokens.address").
+		Select("erc721_tokens.*, tokens.name, tokens.symbol").
+		Where("erc721_tokens.address=? AND erc721_tokens.token_id=?", addr, tokenId).Take(&res.AccountNFT).Error
+	if err != nil {
+		return
+	}
+	err = DB.Where("address=? AND token_id=?", addr, tokenId).Order("block_number, log_index").Find(&res.Transfers).Error
+	return
+}
+
+// saveInventory sets the owners of the ERC721 tokens transferred by the block
+func saveInventory(db *gorm.DB, parsed *model.Parsed) error {
+	var tokens []*model.ERC721Token
+	index := make(map[model.ERC721Token]int)
+	for _, transferLog := range parsed.CacheTransferLogs {
+		if transfer, ok := transferLog.(*model.ERC721Transfer); ok {
+			// the last transfer of the token in the block decides its owner
+			key := model.ERC721Token{Address: transfer.Address, TokenId: transfer.TokenId}
+			token := &model.ERC721Token{
+				Address:     transfer.Address,
+				TokenId:     transfer.TokenId,
+				Owner:       transfer.To,
+				BlockNumber: parsed.Number,
+				TxHash:      transfer.TxHash,
+			}
+			if i, ok := index[key]; ok {
+				tokens[i] = token
+			} else {
+				index[key] = len(tokens)
+				tokens = append(tokens, token)
+			}
+		}
+	}
+	if len(tokens) == 0 {
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).Create(tokens).Error
+}
+
+// inventoryTokens returns the ERC721 tokens transferred in the blocks from..to
+func inventoryTokens(db *gorm.DB, from, to types.Long) (tokens []*model.ERC721Token, err error) {
+	err = db.Model(&model.ERC721Transfer{}).Where("block_number BETWEEN ? AND ?", from, to).Distinct("address", "token_id").Scan(&tokens).Error
+	return
+}
+
+// rebuildInventory sets the owners of the ERC721 tokens again from their stored transfers,
+// the tokens without any transfer left are removed
+func rebuildInventory(db *gorm.DB, tokens []*model.ERC721Token) (err error) {
+	for _, token := range tokens {
+		var last []*model.ERC721Transfer
+		err = db.Where("address=? AND token_id=?", token.Address, token.TokenId).
+			Order("block_number DESC, log_index DESC").Limit(1).Find(&last).Error
+		if err != nil {
+			return
+		}
+		if len(last) == 0 {
+			err = db.Delete(&model.ERC721Token{}, "address=? AND token_id=?", token.Address, token.TokenId).Error
+		} else {
+			token.Owner, token.BlockNumber, token.TxHash = last[0].To, last[0].BlockNumber, last[0].TxHash
+			err = db.Clauses(clause.OnConflict{UpdateAll: true}).Create(token).Error
+		}
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// initInventory sets the owners of all the ERC721 tokens from the stored transfers, when the transfers were stored
+// before the inventory was kept
+func initInventory(db *gorm.DB) (err error) {
+	var exists bool
+	if err = db.Model(&model.ERC721Token{}).Select("COUNT(*)>0").Scan(&exists).Error; err != nil || exists {
+		return
+	}
+	return db.Exec("INSERT INTO erc721_tokens (address, token_id, owner, block_number, tx_hash) " +
+		"SELECT address, token_id, `to`, block_number, tx_hash FROM erc721_transfers t WHERE NOT EXISTS (" +
+		"SELECT 1 FROM erc721_transfers t2 WHERE t2.address=t.address AND t2.token_id=t.token_id AND " +
+		"(t2.block_number>t.block_number OR (t2.block_number=t.block_number AND t2.log_index>t.log_index))" +
+		")" + dialect.upsert([]string{"address", "token_id"}, "owner")).Error
+}
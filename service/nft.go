@@ -56,7 +56,7 @@ type SNFTRes struct {
 }
 
 func GetSNFT(addr string) (res SNFTRes, err error) {
-	db := DB.Model(&model.SNFT{}).Joins("LEFT JOIN epoches ON LEFT(address, 39) = epoches.id")
+	db := DB.Model(&model.SNFT{}).Joins("LEFT JOIN epoches ON SUBSTR(address,1,39) = epoches.id")
 	err = db.Select("snfts.*, creator, timestamp AS created_at, meta_url, royalty_ratio").Where("address=?", addr).Scan(&res).Error
 	return
 }
@@ -68,7 +68,7 @@ type SNFTsAndMetaRes struct {
 }
 
 func FetchSNFTsAndMeta(owner string, page, size int) (res SNFTsAndMetaRes, err error) {
-	db := DB.Model(&model.SNFT{}).Joins("LEFT JOIN epoches ON LEFT(address, 39) = epoches.id").Where("`remove`=false")
+	db := DB.Model(&model.SNFT{}).Joins("LEFT JOIN epoches ON SUBSTR(address,1,39) = epoches.id").Where("`remove`=false")
 	if owner != "" {
 		db = db.Where("owner=?", owner)
 	}
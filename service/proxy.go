@@ -0,0 +1,46 @@
+package service
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"server/common/model"
+	"server/common/types"
+)
+
+// saveProxies writes the implementations of the proxies created or upgraded by the block
+func saveProxies(db *gorm.DB, parsed *model.Parsed) error {
+	if len(parsed.CacheProxies) == 0 {
+		return nil
+	}
+	return db.Clauses(clause.OnConflict{UpdateAll: true}).Create(parsed.CacheProxies).Error
+}
+
+// FetchProxyImplementations returns the implementation history of the proxy, the latest first, with the upgrades of
+// its beacon for a beacon proxy
+func FetchProxyImplementations(addr string) (res []*model.ProxyImplementation, err error) {
+	address := strings.ToLower(addr)
+	beacons := DB.Model(&model.ProxyImplementation{}).Select("beacon").Where("address=? AND beacon IS NOT NULL", address)
+	err = DB.Where("address=? OR address IN (?)", address, beacons).Order("block_number DESC").Find(&res).Error
+	return
+}
+
+// implementationOf returns the current implementation of the proxy, nil when the contract is not a known proxy
+func implementationOf(address types.Address) (implementation *types.Address, err error) {
+	var proxy model.ProxyImplementation
+	if err = DB.Where("address=?", address).Order("block_number DESC").Limit(1).Find(&proxy).Error; err != nil || proxy.Address == "" {
+		return
+	}
+	if proxy.Beacon != nil {
+		// the beacon upgraded after the proxy was created or upgraded
+		var beacon model.ProxyImplementation
+		if err = DB.Where("address=? AND block_number>?", *proxy.Beacon, proxy.BlockNumber).Order("block_number DESC").Limit(1).Find(&beacon).Error; err != nil {
+			return
+		}
+		if beacon.Address != "" {
+			return &beacon.Implementation, nil
+		}
+	}
+	return &proxy.Implementation, nil
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"testing"
+
+	"server/common/model"
+	"server/common/types"
+)
+
+func TestImplementationOf(t *testing.T) {
+	openTestDB(t)
+	proxy, beacon := types.Address("0x00000000000000000000000000000000000000d1"), types.Address("0x00000000000000000000000000000000000000d2")
+	first, second := types.Address("0x00000000000000000000000000000000000000e1"), types.Address("0x00000000000000000000000000000000000000e2")
+	if implementation, err := implementationOf(proxy); err != nil || implementation != nil {
+		t.Fatal("not a proxy", implementation, err)
+	}
+	err := DB.Create([]*model.ProxyImplementation{
+		{Address: beacon, BlockNumber: 5, Kind: "beacon", Implementation: first},
+		{Address: proxy, BlockNumber: 10, Kind: "eip1967-beacon", Implementation: first, Beacon: &beacon},
+	}).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	if implementation, err := implementationOf(proxy); err != nil || implementation == nil || *implementation != first {
+		t.Fatal("beacon proxy", implementation, err)
+	}
+	// the beacon upgraded after the proxy was created
+	if err = DB.Create(&model.ProxyImplementation{Address: beacon, BlockNumber: 12, Kind: "beacon", Implementation: second}).Error; err != nil {
+		t.Fatal(err)
+	}
+	if implementation, err := implementationOf(proxy); err != nil || implementation == nil || *implementation != second {
+		t.Fatal("upgraded beacon", implementation, err)
+	}
+}
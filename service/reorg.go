@@ -0,0 +1,52 @@
+package service
+
+import (
+	"server/common/model"
+)
+
+// ReorgsRes reorg paging return parameters
+type ReorgsRes struct {
+	Total  int64          `json:"total"`  //The total number of reorgs
+	Reorgs []*model.Reorg `json:"reorgs"` //reorg list
+}
+
+func FetchReorgs(page, size int) (res ReorgsRes, err error) {
+	db := DB.Model(&model.Reorg{})
+	if err = db.Count(&res.Total).Error; err != nil {
+		return
+	}
+	err = db.Order("id DESC").Offset((page - 1) * size).Limit(size).Find(&res.Reorgs).Error
+	return
+}
+
+// ReorgRes reorg with its orphaned transactions
+type ReorgRes struct {
+	model.Reorg
+	Transactions []*model.OrphanedTx `json:"transactions"` //orphaned transactions
+}
+
+func GetReorg(id string) (res ReorgRes, err error) {
+	if err = DB.Where("id=?", id).Take(&res.Reorg).Error; err != nil {
+		return
+	}
+	err = DB.Where("reorg_id=?", id).Order("block_number, tx_index").Find(&res.Transactions).Error
+	return
+}
+
+// OrphanedTxRes orphaned records of a transaction
+type OrphanedTxRes struct {
+	Orphaned bool                `json:"orphaned"` //whether the transaction was ever in an orphaned block
+	Included bool                `json:"included"` //whether the transaction is in a block of the current chain
+	Records  []*model.OrphanedTx `json:"records"`  //the transaction in the orphaned blocks, by reorg
+}
+
+func GetOrphanedTx(hash string) (res OrphanedTxRes, err error) {
+	if err = DB.Where("hash=?", hash).Order("reorg_id").Find(&res.Records).Error; err != nil {
+		return
+	}
+	if err = DB.Model(&model.Transaction{}).Where("hash=?", hash).Select("COUNT(*)>0").Scan(&res.Included).Error; err != nil {
+		return
+	}
+	res.Orphaned = len(res.Records) > 0
+	return
+}
@@ -4,13 +4,22 @@ import "server/common/model"
 
 // RewardsRes reward paging return parameters
 type RewardsRes struct {
-	Total   int64           `json:"total"`   //The total number of rewards
-	Rewards []*model.Reward `json:"rewards"` //Rewards list
+	Total   int64           `json:"total"`          //The total number of rewards
+	Rewards []*model.Reward `json:"rewards"`        //Rewards list
+	Next    string          `json:"next,omitempty"` //cursor of the next page, empty on the last page
 }
 
-func FetchRewards(page, size int) (res RewardsRes, err error) {
-	err = DB.Order("block_number DESC").Offset((page - 1) * size).Limit(size).Find(&res.Rewards).Error
+func FetchRewards(page, size int, cursor string) (res RewardsRes, err error) {
+	db, err := paginate(DB, page, size, cursor, "block_number", "identity", "address", "snft")
+	if err != nil {
+		return
+	}
+	err = db.Find(&res.Rewards).Error
 	res.Total = (stats.TotalBlock - stats.TotalBlackHole - 1) * 11
+	if n := len(res.Rewards); n > 0 {
+		last := res.Rewards[n-1]
+		res.Next = nextCursor(size, n, last.BlockNumber, last.Identity, last.Address, last.SNFT)
+	}
 	return
 }
 
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"server/common/model"
+	"server/common/types"
+)
+
+var (
+	numberRegexp = regexp.MustCompile(`^[0-9]+$`)
+	hexRegexp    = regexp.MustCompile(`^0x[0-9a-f]+$`)
+	likeEscaper  = strings.NewReplacer(`!`, `!!`, `%`, `!%`, `_`, `!_`)
+)
+
+// SearchResult an object matching the search
+type SearchResult struct {
+	Type   string              `json:"type"`             //block, transaction, account, contract, token, nft, snft or epoch
+	Key    string              `json:"key"`              //block number, transaction hash, address or epoch ID, the parameter of the query route of the type
+	Name   *string             `json:"name,omitempty"`   //contract name
+	Symbol *string             `json:"symbol,omitempty"` //contract symbol
+	Token  *types.ContractType `json:"token,omitempty"`  //contract type, ERC20, ERC721 or ERC1155
+}
+
+// SearchRes search return parameters
+type SearchRes struct {
+	Query   string          `json:"query"`   //the query as classified
+	Results []*SearchResult `json:"results"` //matching objects, empty when nothing matches
+}
+
+// Search classifies the query as a block number, a hash, an address, an SNFT address or an epoch ID and looks for it in
+// the tables of its kind, any other query is matched as a prefix of the contract names and symbols
+func Search(q string, limit int) (res SearchRes, err error) {
+	res.Query, res.Results = strings.TrimSpace(q), []*SearchResult{}
+	if res.Query == "" {
+		return
+	}
+	query := strings.ToLower(res.Query)
+	found := func(table any, column, t string) error {
+		var count int64
+		if err := DB.Model(table).Where(column+"=?", query).Limit(1).Count(&count).Error; err != nil || count == 0 {
+			return err
+		}
+		res.Results = append(res.Results, &SearchResult{Type: t, Key: query})
+		return nil
+	}
+	switch {
+	case numberRegexp.MatchString(query):
+		err = found(&model.Block{}, "number", "block")
+	case hexRegexp.MatchString(query) && len(query) == 66:
+		if err = found(&model.Transaction{}, "hash", "transaction"); err != nil {
+			return
+		}
+		var number *int64
+		if err = DB.Model(&model.Block{}).Select("number").Where("hash=?", query).Scan(&number).Error; err == nil && number != nil {
+			res.Results = append(res.Results, &SearchResult{Type: "block", Key: strconv.FormatInt(*number, 10)})
+		}
+	case hexRegexp.MatchString(query) && len(query) == 42:
+		var account model.Account
+		if err = DB.Select("address", "name", "symbol", "type", "code").Where("address=?", query).Limit(1).Find(&account).Error; err != nil {
+			return
+		}
+		if account.Address != "" {
+			result := &SearchResult{Type: "account", Key: query, Name: account.Name, Symbol: account.Symbol, Token: account.Type}
+			if account.Code != nil && *account.Code != "" && *account.Code != "0x" {
+				result.Type = "contract"
+			}
+			res.Results = append(res.Results, result)
+		}
+		if err = found(&model.NFT{}, "address", "nft"); err != nil {
+			return
+		}
+		err = found(&model.SNFT{}, "address", "snft")
+	case hexRegexp.MatchString(query) && len(query) == 39 && strings.HasPrefix(query, "0x8"):
+		// the SNFT addresses start with the epoch ID
+		err = found(&model.Epoch{}, "id", "epoch")
+	case hexRegexp.MatchString(query) && len(query) < 42 && strings.HasPrefix(query, "0x8"):
+		// the synthesized SNFTs have shorter addresses
+		err = found(&model.SNFT{}, "address", "snft")
+	default:
+		var accounts []*model.Account
+		prefix := likeEscaper.Replace(res.Query) + "%"
+		like := dialect.like() + " ? ESCAPE '!'"
+		err = DB.Select("address", "name", "symbol", "type").Where("name "+like+" OR symbol "+like, prefix, prefix).
+			Order("name").Limit(limit).Find(&accounts).Error
+		for _, account := range accounts {
+			t := "contract"
+			if account.Type != nil && (*account.Type == types.ERC20 || *account.Type == types.ERC721 || *account.Type == types.ERC1155) {
+				t = "token"
+			}
+			res.Results = append(res.Results, &SearchResult{Type: t, Key: string(account.Address), Name: account.Name, Symbol: account.Symbol, Token: account.Type})
+		}
+	}
+	return
+}
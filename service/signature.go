@@ -0,0 +1,82 @@
+package service
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"server/common/model"
+	"server/common/types"
+	"server/common/utils"
+)
+
+// SignatureReq text signature to add to the signature database
+type SignatureReq struct {
+	Kind      string `json:"kind"`      //function or event
+	Signature string `json:"signature"` //text signature, e.g. transfer(address,uint256)
+}
+
+// SaveSignatures adds the text signatures to the signature database, they are known once they are stored
+func SaveSignatures(reqs []SignatureReq) (res []*model.Signature, err error) {
+	now := types.Long(time.Now().Unix())
+	for _, req := range reqs {
+		signature, hash, err := utils.SignatureHash(req.Kind, req.Signature)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, &model.Signature{Kind: req.Kind, Signature: signature, Hash: hash, Timestamp: now})
+	}
+	if len(res) == 0 {
+		return
+	}
+	if err = DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&res).Error; err != nil {
+		return nil, err
+	}
+	for _, s := range res {
+		utils.RegisterSignature(s.Kind, s.Signature)
+	}
+	return
+}
+
+// GetSignatures returns the known text signatures of the 4 bytes selector or 32 bytes topic
+func GetSignatures(hash string) []string {
+	return utils.LookupSignatures(hash)
+}
+
+// initSignatures adds the stored signatures to the built-in ones
+func initSignatures(db *gorm.DB) (err error) {
+	var stored []*model.Signature
+	if err = db.Find(&stored).Error; err != nil {
+		return
+	}
+	for _, s := range stored {
+		utils.RegisterSignature(s.Kind, s.Signature)
+	}
+	return
+}
+
+// setMethod sets the best effort text signature of the function called by the transaction
+func setMethod(tx *model.Transaction) {
+	if tx.To == nil {
+		return
+	}
+	tx.Method, tx.MethodCandidates = bestSignature(utils.MethodSignatures(tx.Input))
+}
+
+// setEvent sets the best effort text signature of the event of the log
+func setEvent(log *model.EventLog) {
+	log.Event, log.EventCandidates = bestSignature(utils.EventSignatures(log.Topics))
+}
+
+// bestSignature returns the first known signature, and all of them when there are several
+func bestSignature(signatures []string) (best *string, candidates []string) {
+	if len(signatures) == 0 {
+		return
+	}
+	best = new(string)
+	*best = signatures[0]
+	if len(signatures) > 1 {
+		candidates = append([]string(nil), signatures...)
+	}
+	return
+}
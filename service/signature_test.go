@@ -0,0 +1,47 @@
+package service
+
+import (
+	"sync"
+	"testing"
+
+	"server/common/model"
+	"server/common/utils"
+)
+
+func TestSaveSignatures(t *testing.T) {
+	openTestDB(t)
+	// a signature that is not stored is not known
+	if err := DB.Migrator().DropTable(&model.Signature{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SaveSignatures([]SignatureReq{{"function", "unstoredSignature(uint256)"}}); err == nil {
+		t.Fatal("saved without the table")
+	}
+	if _, hash, _ := utils.SignatureHash("function", "unstoredSignature(uint256)"); GetSignatures(hash) != nil {
+		t.Fatal("unstored signature registered")
+	}
+	if err := DB.Migrator().CreateTable(&model.Signature{}); err != nil {
+		t.Fatal(err)
+	}
+	// the stored signatures are read while they are added
+	var wg sync.WaitGroup
+	for _, text := range []string{"Stored(uint256)", "Stored(uint8)"} {
+		wg.Add(1)
+		go func(text string) {
+			defer wg.Done()
+			if _, err := SaveSignatures([]SignatureReq{{"event", text}}); err != nil {
+				t.Error(err)
+			}
+		}(text)
+	}
+	_, hash, _ := utils.SignatureHash("event", "Stored(uint256)")
+	for i := 0; i < 100; i++ {
+		if known := GetSignatures(hash); len(known) > 0 {
+			known[0] = "changed()"
+		}
+	}
+	wg.Wait()
+	if best, _ := bestSignature(GetSignatures(hash)); best == nil || *best == "changed()" {
+		t.Fatal("stored signature", best)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"server/common/model"
 	"server/common/types"
 	"server/common/utils"
+	"server/conf"
 )
 
 var stats = &model.Stats{
@@ -58,13 +59,13 @@ func loadStats(db *gorm.DB) (err error) {
 	if err = db.Model(&model.Reward{}).Select("COUNT(amount)").Scan(&stats.RewardCoinCount).Error; err != nil {
 		return
 	}
-	if err = db.Model(&model.Erbie{}).Select("IFNULL(SUM(fee_rate),0)").Scan(&stats.TotalRecycle).Error; err != nil {
+	if err = db.Model(&model.Erbie{}).Select("COALESCE(SUM(fee_rate),0)").Scan(&stats.TotalRecycle).Error; err != nil {
 		return
 	}
-	if err = db.Model(&model.Erbie{}).Where("LEFT(address,3)='0x0'").Count(&stats.TotalNFTTx).Error; err != nil {
+	if err = db.Model(&model.Erbie{}).Where("SUBSTR(address,1,3)='0x0'").Count(&stats.TotalNFTTx).Error; err != nil {
 		return
 	}
-	if err = db.Model(&model.Erbie{}).Where("LEFT(address,3)='0x8'").Count(&stats.TotalSNFTTx).Error; err != nil {
+	if err = db.Model(&model.Erbie{}).Where("SUBSTR(address,1,3)='0x8'").Count(&stats.TotalSNFTTx).Error; err != nil {
 		return
 	}
 	if err = db.Model(&model.Epoch{}).Count(&stats.TotalEpoch).Error; err != nil {
@@ -313,3 +314,17 @@ func freshStats(db *gorm.DB, parsed *model.Parsed) {
 func GetStats() *model.Stats {
 	return stats
 }
+
+// confirm returns the confirmations of the block number and whether it is deep enough below the chain head to be
+// final. The stored head is used when it is ahead of the chain head the indexer saw, e.g. when only the API is running
+// and the stats are reloaded by WatchStats
+func confirm(number types.Long) (confirmations int64, finalized bool) {
+	head := stats.ChainHead
+	if stored := stats.TotalBlock - 1; stored > head {
+		head = stored
+	}
+	if confirmations = head - int64(number) + 1; confirmations < 0 {
+		confirmations = 0
+	}
+	return confirmations, confirmations > conf.Confirmations
+}
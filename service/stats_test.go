@@ -0,0 +1,33 @@
+package service
+
+import (
+	"testing"
+
+	"server/common/types"
+	"server/conf"
+)
+
+func TestConfirm(t *testing.T) {
+	defer func(head, total, depth int64) {
+		stats.ChainHead, stats.TotalBlock, conf.Confirmations = head, total, depth
+	}(stats.ChainHead, stats.TotalBlock, conf.Confirmations)
+	conf.Confirmations = 6
+	for _, c := range []struct {
+		chainHead, totalBlock int64
+		number                types.Long
+		confirmations         int64
+		finalized             bool
+	}{
+		{0, 100, 99, 1, false},   // only the API runs, the stored head is used
+		{0, 100, 93, 7, true},    // deeper than the confirmation depth
+		{120, 100, 99, 22, true}, // the indexer saw the chain head ahead of the stored blocks
+		{0, 110, 99, 11, true},   // the stats reloaded by WatchStats advance the confirmations
+		{100, 100, 150, 0, false},
+	} {
+		stats.ChainHead, stats.TotalBlock = c.chainHead, c.totalBlock
+		if confirmations, finalized := confirm(c.number); confirmations != c.confirmations || finalized != c.finalized {
+			t.Errorf("head %v, %v blocks: block %v has %v confirmations, finalized %v, want %v, %v", c.chainHead,
+				c.totalBlock, c.number, confirmations, finalized, c.confirmations, c.finalized)
+		}
+	}
+}
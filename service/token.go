@@ -0,0 +1,486 @@
+package service
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"server/common/model"
+	"server/common/types"
+)
+
+// positiveBalance filters the holders, the balances are text to hold any uint256 and the zero balances are deleted
+const positiveBalance = "balance NOT LIKE '-%'"
+
+// tokenOrders are the columns the token list can be sorted by
+var tokenOrders = map[string]string{
+	"holderCount":   "holder_count",
+	"transferCount": "transfer_count",
+	"totalSupply":   "total_supply",
+	"blockNumber":   "block_number",
+}
+
+// TokensRes token paging return parameters
+type TokensRes struct {
+	Total  int64          `json:"total"`  //The total number of tokens
+	Tokens []*model.Token `json:"tokens"` //token list
+}
+
+func FetchTokens(page, size int, tokenType, order string) (res TokensRes, err error) {
+	db := DB.Model(&model.Token{})
+	if tokenType != "" {
+		var t types.ContractType
+		if err = t.UnmarshalGraphQL(tokenType); err != nil || t == 0 {
+			return res, fmt.Errorf("unknown token type %q", tokenType)
+		}
+		db = db.Where("type=?", t)
+	}
+	if err = db.Count(&res.Total).Error; err != nil {
+		return
+	}
+	// order is a column name, optionally followed by asc or desc
+	if order == "" {
+		order = "holderCount desc"
+	}
+	field, direction, _ := strings.Cut(order, " ")
+	column, ok := tokenOrders[field]
+	if direction = strings.ToUpper(direction); !ok || (direction != "" && direction != "ASC" && direction != "DESC") {
+		return res, fmt.Errorf("unsupported order %q", order)
+	}
+	if column == "total_supply" {
+		// the supply is text, of two numbers the longer one is larger
+		db = db.Order(strings.TrimSpace("LENGTH(total_supply) " + direction))
+	}
+	err = db.Order(strings.TrimSpace(column + " " + direction)).Order("address").Offset((page - 1) * size).Limit(size).Find(&res.Tokens).Error
+	return
+}
+
+func GetToken(addr string) (res model.Token, err error) {
+	err = DB.Where("address=?", addr).Take(&res).Error
+	return
+}
+
+// TokenHolder token balance with its share of the total supply
+type TokenHolder struct {
+	model.TokenBalance
+	Percentage *float64 `json:"percentage,omitempty"` //share of the total supply in percent, empty for ERC1155 or without the total supply
+}
+
+// TokenHoldersRes token holder paging return parameters
+type TokenHoldersRes struct {
+	Total   int64          `json:"total"`   //The total number of holders
+	Holders []*TokenHolder `json:"holders"` //holder list, by balance
+}
+
+func FetchTokenHolders(addr, tokenId string, page, size int) (res TokenHoldersRes, err error) {
+	token, err := GetToken(addr)
+	if err != nil {
+		return
+	}
+	db := DB.Model(&model.TokenBalance{}).Where("address=? AND "+positiveBalance, addr)
+	if tokenId != "" {
+		db = db.Where("token_id=?", tokenId)
+	}
+	if err = db.Count(&res.Total).Error; err != nil {
+		return
+	}
+	if err = db.Order("LENGTH(balance) DESC, balance DESC, holder").Offset((page - 1) * size).Limit(size).Scan(&res.Holders).Error; err != nil {
+		return
+	}
+	if *token.Type != types.ERC1155 {
+		for _, holder := range res.Holders {
+			holder.Percentage = percentage(holder.Balance, token.TotalSupply)
+		}
+	}
+	return
+}
+
+// AccountToken token balance of an account with the token properties
+type AccountToken struct {
+	model.TokenBalance
+	Type        *types.ContractType `json:"type"`                  //contract type, NONE when the contract is not a registered token
+	Name        *string             `json:"name,omitempty"`        //name
+	Symbol      *string             `json:"symbol,omitempty"`      //symbol
+	Decimals    *types.Long         `json:"decimals,omitempty"`    //ERC20 decimals
+	TotalSupply *types.BigInt       `json:"totalSupply,omitempty"` //total supply
+	Percentage  *float64            `json:"percentage,omitempty"`  //share of the total supply in percent, empty for ERC1155 or without the total supply
+}
+
+// AccountTokensRes account token paging return parameters
+type AccountTokensRes struct {
+	Total  int64           `json:"total"`  //The total number of token balances
+	Tokens []*AccountToken `json:"tokens"` //token balance list
+}
+
+func FetchAccountTokens(addr string, page, size int) (res AccountTokensRes, err error) {
+	db := DB.Model(&model.TokenBalance{}).Where("holder=? AND "+positiveBalance, addr)
+	if err = db.Count(&res.Total).Error; err != nil {
+		return
+	}
+	err = db.Joins("LEFT JOIN tokens ON tokens.address=token_balances.address").
+		Select("token_balances.*, tokens.type, tokens.name, tokens.symbol, tokens.decimals, tokens.total_supply").
+		Order("token_balances.address, token_balances.token_id").Offset((page - 1) * size).Limit(size).Scan(&res.Tokens).Error
+	if err != nil {
+		return
+	}
+	for _, token := range res.Tokens {
+		if token.Type != nil && *token.Type != types.ERC1155 {
+			token.Percentage = percentage(token.Balance, token.TotalSupply)
+		}
+	}
+	return
+}
+
+// percentage returns the share of the balance in the supply in percent, nil without a supply
+func percentage(balance types.BigInt, supply *types.BigInt) *float64 {
+	if supply == nil {
+		return nil
+	}
+	total, ok := new(big.Int).SetString(string(*supply), 10)
+	if !ok || total.Sign() <= 0 {
+		return nil
+	}
+	value, ok := new(big.Int).SetString(string(balance), 10)
+	if !ok {
+		return nil
+	}
+	share, _ := new(big.Rat).SetFrac(value.Mul(value, big.NewInt(100)), total).Float64()
+	return &share
+}
+
+// saveTokens writes the tokens created by the block and the total supply of the tokens it transferred,
+// the tokens registered after their first transfers are counted from the stored transfers
+func saveTokens(db *gorm.DB, parsed *model.Parsed) (err error) {
+	var created []types.Address
+	for _, token := range parsed.CacheTokens {
+		if token.Type != nil {
+			res := db.Clauses(clause.OnConflict{DoNothing: true}).Create(token)
+			if err = res.Error; err == nil && res.RowsAffected > 0 {
+				created = append(created, token.Address)
+			}
+		} else if token.TotalSupply != nil {
+			err = db.Model(&model.Token{}).Where("address=? AND supply_number<=?", token.Address, token.SupplyNumber).
+				Updates(map[string]any{"total_supply": token.TotalSupply, "supply_number": token.SupplyNumber}).Error
+		}
+		if err != nil {
+			return
+		}
+	}
+	return refreshTokens(db, created)
+}
+
+// UnregisteredTokens returns the ERC20, ERC721 and ERC1155 contracts of the accounts that are not in the tokens,
+// the accounts stored before the tokens were kept
+func UnregisteredTokens(size int) (res []*model.Token, err error) {
+	err = DB.Model(&model.Account{}).
+		Select("accounts.address, accounts.type, accounts.name, accounts.symbol, accounts.creator, accounts.created_tx, "+
+			"accounts.timestamp, COALESCE(x.block_number,0) AS block_number").
+		Joins("LEFT JOIN transactions x ON x.hash=accounts.created_tx").
+		Where("accounts.type IN ? AND NOT EXISTS (SELECT 1 FROM tokens t WHERE t.address=accounts.address)",
+			[]types.ContractType{types.ERC20, types.ERC721, types.ERC1155}).
+		Order("accounts.address").Limit(size).Scan(&res).Error
+	return
+}
+
+// RegisterTokens writes the tokens returned by UnregisteredTokens and counts their holders and transfers
+func RegisterTokens(tokens []*model.Token) error {
+	return DB.Transaction(func(db *gorm.DB) error {
+		return saveTokens(db, &model.Parsed{CacheTokens: tokens})
+	})
+}
+
+// revertTransfers takes the stored transfers of the blocks from..to back out of the token balances and counts
+func revertTransfers(db *gorm.DB, from, to types.Long) (err error) {
+	var erc20 []*model.ERC20Transfer
+	if err = db.Where("block_number BETWEEN ? AND ?", from, to).Find(&erc20).Error; err != nil {
+		return
+	}
+	var erc721 []*model.ERC721Transfer
+	if err = db.Where("block_number BETWEEN ? AND ?", from, to).Find(&erc721).Error; err != nil {
+		return
+	}
+	var erc1155 []*model.ERC1155Transfer
+	if err = db.Where("block_number BETWEEN ? AND ?", from, to).Find(&erc1155).Error; err != nil {
+		return
+	}
+	transfers := make([]any, 0, len(erc20)+len(erc721)+len(erc1155))
+	for _, transfer := range erc20 {
+		transfers = append(transfers, transfer)
+	}
+	for _, transfer := range erc721 {
+		transfers = append(transfers, transfer)
+	}
+	for _, transfer := range erc1155 {
+		transfers = append(transfers, transfer)
+	}
+	return saveBalances(db, transfers, -1)
+}
+
+// initBalances sums the token balances from all the stored transfers, when the transfers were stored before the
+// balances were kept
+func initBalances(db *gorm.DB) (err error) {
+	var exists bool
+	if err = db.Model(&model.TokenBalance{}).Select("COUNT(*)>0").Scan(&exists).Error; err != nil || exists {
+		return
+	}
+	deltas := make(map[balanceKey]*big.Int)
+	for _, table := range []any{&model.ERC20Transfer{}, &model.ERC721Transfer{}, &model.ERC1155Transfer{}} {
+		rows, err := db.Model(table).Rows()
+		if err != nil {
+			return err
+		}
+		for rows.Next() {
+			transfer := reflect.New(reflect.TypeOf(table).Elem()).Interface()
+			if err = db.ScanRows(rows, transfer); err != nil {
+				rows.Close()
+				return err
+			}
+			addTransfers(deltas, nil, []any{transfer}, 1)
+		}
+		if err = rows.Close(); err != nil {
+			return err
+		}
+	}
+	if err = writeBalances(db, deltas, nil); err != nil {
+		return
+	}
+	var addresses []types.Address
+	if err = db.Model(&model.Token{}).Pluck("address", &addresses).Error; err != nil {
+		return
+	}
+	return refreshTokens(db, addresses)
+}
+
+type balanceKey struct {
+	address, holder types.Address
+	tokenId         types.BigInt
+}
+
+type holderKey struct {
+	address, holder types.Address
+}
+
+// tokenCounts changes of the holder and transfer counts of a token
+type tokenCounts struct {
+	holders, transfers int64
+}
+
+// saveBalances adds the transfers to the token balances and counts, or takes them out with the sign -1
+func saveBalances(db *gorm.DB, transfers []any, sign int64) (err error) {
+	deltas, counts := make(map[balanceKey]*big.Int), make(map[types.Address]*tokenCounts)
+	addTransfers(deltas, counts, transfers, sign)
+	if err = writeBalances(db, deltas, counts); err != nil {
+		return
+	}
+	for address, count := range counts {
+		if count.holders == 0 && count.transfers == 0 {
+			continue
+		}
+		err = db.Model(&model.Token{}).Where("address=?", address).Updates(map[string]any{
+			"holder_count":   gorm.Expr("holder_count+?", count.holders),
+			"transfer_count": gorm.Expr("transfer_count+?", count.transfers),
+		}).Error
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// addTransfers adds the balance changes of the transfers times the sign to the deltas, and the sign to the transfer
+// counts when they are kept
+func addTransfers(deltas map[balanceKey]*big.Int, counts map[types.Address]*tokenCounts, transfers []any, sign int64) {
+	add := func(address, holder types.Address, tokenId types.BigInt, value *big.Int) {
+		if holder == types.ZeroAddress {
+			return
+		}
+		key := balanceKey{address, holder, tokenId}
+		if deltas[key] == nil {
+			deltas[key] = new(big.Int)
+		}
+		deltas[key].Add(deltas[key], value)
+	}
+	for _, transfer := range transfers {
+		var address, from, to types.Address
+		var tokenId types.BigInt
+		value := big.NewInt(1)
+		switch transfer := transfer.(type) {
+		case *model.ERC20Transfer:
+			address, from, to = transfer.Address, transfer.From, transfer.To
+			value.SetString(string(transfer.Value), 10)
+		case *model.ERC721Transfer:
+			address, from, to = transfer.Address, transfer.From, transfer.To
+		case *model.ERC1155Transfer:
+			address, from, to, tokenId = transfer.Address, transfer.From, transfer.To, transfer.TokenId
+			value.SetString(string(transfer.Value), 10)
+		default:
+			continue
+		}
+		value.Mul(value, big.NewInt(sign))
+		add(address, to, tokenId, value)
+		add(address, from, tokenId, new(big.Int).Neg(value))
+		if counts != nil {
+			if counts[address] == nil {
+				counts[address] = &tokenCounts{}
+			}
+			counts[address].transfers += sign
+		}
+	}
+}
+
+// balanceBatch number of balances looked up or deleted by one statement
+const balanceBatch = 500
+
+// writeBalances adds the deltas to the stored balances and deletes the balances that become zero, and the change of
+// the holders to the counts when they are kept. The sums are done here, a uint256 has up to 78 digits, more than the
+// databases can add
+func writeBalances(db *gorm.DB, deltas map[balanceKey]*big.Int, counts map[types.Address]*tokenCounts) (err error) {
+	// all the balances of the holders are loaded, a holder is counted while one of them is positive
+	pairs, seen := make([][]any, 0, len(deltas)), make(map[holderKey]bool)
+	for key, delta := range deltas {
+		if pair := (holderKey{key.address, key.holder}); delta.Sign() != 0 && !seen[pair] {
+			seen[pair] = true
+			pairs = append(pairs, []any{key.address, key.holder})
+		}
+	}
+	balances, positive := make(map[balanceKey]*big.Int, len(deltas)), make(map[holderKey]int)
+	for _, batch := range batches(pairs) {
+		var stored []*model.TokenBalance
+		if err = db.Where("(address, holder) IN ?", batch).Find(&stored).Error; err != nil {
+			return
+		}
+		for _, balance := range stored {
+			value, _ := new(big.Int).SetString(string(balance.Balance), 10)
+			if value == nil {
+				value = new(big.Int)
+			}
+			balances[balanceKey{balance.Address, balance.Holder, balance.TokenId}] = value
+			if value.Sign() > 0 {
+				positive[holderKey{balance.Address, balance.Holder}]++
+			}
+		}
+	}
+	held := make(map[holderKey]bool, len(positive))
+	for pair, n := range positive {
+		held[pair] = n > 0
+	}
+	changed, removed := make([]*model.TokenBalance, 0, len(deltas)), make([][]any, 0)
+	for key, delta := range deltas {
+		if delta.Sign() == 0 {
+			continue
+		}
+		balance, pair := new(big.Int).Set(delta), holderKey{key.address, key.holder}
+		if stored := balances[key]; stored != nil {
+			if stored.Sign() > 0 {
+				positive[pair]--
+			}
+			balance.Add(balance, stored)
+		}
+		if balance.Sign() > 0 {
+			positive[pair]++
+		}
+		if balance.Sign() == 0 {
+			removed = append(removed, []any{key.address, key.holder, key.tokenId})
+		} else {
+			changed = append(changed, &model.TokenBalance{
+				Address: key.address,
+				Holder:  key.holder,
+				TokenId: key.tokenId,
+				Balance: types.BigInt(balance.String()),
+			})
+		}
+	}
+	if counts != nil {
+		for pair := range seen {
+			var change int64
+			if was, is := held[pair], positive[pair] > 0; is && !was {
+				change = 1
+			} else if was && !is {
+				change = -1
+			}
+			if change != 0 {
+				if counts[pair.address] == nil {
+					counts[pair.address] = &tokenCounts{}
+				}
+				counts[pair.address].holders += change
+			}
+		}
+	}
+	if len(changed) > 0 {
+		err = db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "address"}, {Name: "holder"}, {Name: "token_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"balance"}),
+		}).CreateInBatches(changed, 1000).Error
+		if err != nil {
+			return
+		}
+	}
+	// the holders that sent all their tokens are no longer holders
+	for _, batch := range batches(removed) {
+		if err = db.Delete(&model.TokenBalance{}, "(address, holder, token_id) IN ?", batch).Error; err != nil {
+			return
+		}
+	}
+	return
+}
+
+// batches splits the balance keys into batches of balanceBatch keys
+func batches(keys [][]any) (res [][][]any) {
+	for len(keys) > balanceBatch {
+		res, keys = append(res, keys[:balanceBatch]), keys[balanceBatch:]
+	}
+	if len(keys) > 0 {
+		res = append(res, keys)
+	}
+	return
+}
+
+// refreshTokens counts the holders and transfers of the tokens from the stored transfers, saveBalances keeps the
+// counts of the registered tokens from then on
+func refreshTokens(db *gorm.DB, addresses []types.Address) (err error) {
+	if len(addresses) == 0 {
+		return
+	}
+	var tokens []*model.Token
+	if err = db.Select("address", "type").Where("address IN ?", addresses).Find(&tokens).Error; err != nil {
+		return
+	}
+	for _, token := range tokens {
+		var holderCount, transferCount int64
+		if holderCount, err = countHolders(db, token); err != nil {
+			return
+		}
+		if transferCount, err = countTransfers(db, token); err != nil {
+			return
+		}
+		err = db.Model(&model.Token{}).Where("address=?", token.Address).
+			Updates(map[string]any{"holder_count": holderCount, "transfer_count": transferCount}).Error
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func countTransfers(db *gorm.DB, token *model.Token) (count int64, err error) {
+	var table any
+	switch *token.Type {
+	case types.ERC20:
+		table = &model.ERC20Transfer{}
+	case types.ERC721:
+		table = &model.ERC721Transfer{}
+	default:
+		table = &model.ERC1155Transfer{}
+	}
+	err = db.Model(table).Where("address=?", token.Address).Count(&count).Error
+	return
+}
+
+// countHolders counts the addresses with a positive balance
+func countHolders(db *gorm.DB, token *model.Token) (count int64, err error) {
+	err = db.Model(&model.TokenBalance{}).Where("address=? AND "+positiveBalance, token.Address).Distinct("holder").Count(&count).Error
+	return
+}
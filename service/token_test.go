@@ -0,0 +1,84 @@
+package service
+
+import (
+	"testing"
+
+	"server/common/model"
+	"server/common/types"
+)
+
+func TestSaveBalances(t *testing.T) {
+	openTestDB(t)
+	erc20, erc1155 := types.Address("0x00000000000000000000000000000000000000c1"), types.Address("0x00000000000000000000000000000000000000c2")
+	a, b := types.Address("0x00000000000000000000000000000000000000a1"), types.Address("0x00000000000000000000000000000000000000a2")
+	if err := DB.Create([]*model.Token{{Address: erc20}, {Address: erc1155}}).Error; err != nil {
+		t.Fatal(err)
+	}
+	check := func(step string, holders map[types.Address]int64, want map[balanceKey]string) {
+		t.Helper()
+		var stored []*model.TokenBalance
+		if err := DB.Find(&stored).Error; err != nil {
+			t.Fatal(err)
+		}
+		balances := make(map[balanceKey]string)
+		for _, balance := range stored {
+			balances[balanceKey{balance.Address, balance.Holder, balance.TokenId}] = string(balance.Balance)
+		}
+		if len(balances) != len(want) {
+			t.Fatalf("%v: balances %v, want %v", step, balances, want)
+		}
+		for key, balance := range want {
+			if balances[key] != balance {
+				t.Fatalf("%v: balances %v, want %v", step, balances, want)
+			}
+		}
+		for address, count := range holders {
+			var token model.Token
+			if DB.Take(&token, "address=?", address); token.HolderCount != count {
+				t.Fatalf("%v: %v has %v holders, want %v", step, address, token.HolderCount, count)
+			}
+		}
+	}
+	// the amounts are beyond 64 bits, the mints from the zero address have no balance
+	mint := []any{
+		&model.ERC20Transfer{Address: erc20, From: types.ZeroAddress, To: a, Value: "100000000000000000000000000000"},
+		&model.ERC1155Transfer{Address: erc1155, From: types.ZeroAddress, To: a, TokenId: "1", Value: "5"},
+		&model.ERC1155Transfer{Address: erc1155, From: types.ZeroAddress, To: a, TokenId: "2", Value: "5"},
+	}
+	if err := saveBalances(DB, mint, 1); err != nil {
+		t.Fatal(err)
+	}
+	check("mint", map[types.Address]int64{erc20: 1, erc1155: 1}, map[balanceKey]string{
+		{erc20, a, ""}:    "100000000000000000000000000000",
+		{erc1155, a, "1"}: "5",
+		{erc1155, a, "2"}: "5",
+	})
+	// a holder is counted while one of its balances is positive, the zero balances are deleted
+	transfer := []any{
+		&model.ERC20Transfer{Address: erc20, From: a, To: b, Value: "100000000000000000000000000000"},
+		&model.ERC1155Transfer{Address: erc1155, From: a, To: b, TokenId: "1", Value: "5"},
+		&model.ERC1155Transfer{Address: erc1155, From: a, To: b, TokenId: "2", Value: "2"},
+	}
+	if err := saveBalances(DB, transfer, 1); err != nil {
+		t.Fatal(err)
+	}
+	check("transfer", map[types.Address]int64{erc20: 1, erc1155: 2}, map[balanceKey]string{
+		{erc20, b, ""}:    "100000000000000000000000000000",
+		{erc1155, a, "2"}: "3",
+		{erc1155, b, "1"}: "5",
+		{erc1155, b, "2"}: "2",
+	})
+	// the transfers taken out restore the balances and the counts
+	if err := saveBalances(DB, transfer, -1); err != nil {
+		t.Fatal(err)
+	}
+	check("revert", map[types.Address]int64{erc20: 1, erc1155: 1}, map[balanceKey]string{
+		{erc20, a, ""}:    "100000000000000000000000000000",
+		{erc1155, a, "1"}: "5",
+		{erc1155, a, "2"}: "5",
+	})
+	var token model.Token
+	if DB.Take(&token, "address=?", erc1155); token.TransferCount != 2 {
+		t.Fatal("transfer count", token.TransferCount)
+	}
+}
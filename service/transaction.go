@@ -1,22 +1,42 @@
 package service
 
 import (
+	"gorm.io/gorm"
 	"server/common/model"
+	"server/common/types"
+	"server/common/utils"
 	"strings"
 )
 
-func GetTransaction(hash string) (res model.Transaction, err error) {
-	err = DB.Where("transactions.hash=?", hash).Take(&res).Error
+// TransactionRes transaction with its token transfers
+type TransactionRes struct {
+	model.Transaction
+	DecodedInput   *utils.ABIDecoded `json:"decodedInput,omitempty"` //called function and arguments, with the ABI of the called contract
+	DecodedError   *utils.ABIDecoded `json:"decodedError,omitempty"` //custom error and arguments of the revert data
+	TokenTransfers TokenTransfers    `json:"tokenTransfers"`         //token transfers of the transaction
+}
+
+func GetTransaction(hash string) (res TransactionRes, err error) {
+	if err = DB.Where("transactions.hash=?", hash).Take(&res.Transaction).Error; err != nil {
+		return
+	}
+	res.Confirmations, res.Finalized = confirm(res.BlockNumber)
+	res.DecodedInput, res.DecodedError = decodeTransaction(&res.Transaction)
+	if setMethod(&res.Transaction); res.DecodedInput != nil {
+		res.Method, res.MethodCandidates = &res.DecodedInput.Signature, nil
+	}
+	res.TokenTransfers, err = getTokenTransfers(hash)
 	return
 }
 
 // TransactionsRes transaction paging return parameters
 type TransactionsRes struct {
-	Total        int64                `json:"total"`        //The total number of transactions
-	Transactions []*model.Transaction `json:"transactions"` //transaction list
+	Total        int64                `json:"total"`          //The total number of transactions, not counted on the filtered pages after a cursor
+	Transactions []*model.Transaction `json:"transactions"`   //transaction list
+	Next         string               `json:"next,omitempty"` //cursor of the next page, empty on the last page
 }
 
-func FetchTransactions(page, size int, number, addr, types string) (res TransactionsRes, err error) {
+func FetchTransactions(page, size int, cursor, number, addr, types string) (res TransactionsRes, err error) {
 	db := DB.Model(&model.Transaction{})
 	if number != "" {
 		db = db.Where("block_number=?", number)
@@ -25,10 +45,13 @@ func FetchTransactions(page, size int, number, addr, types string) (res Transact
 		db = db.Where("`from`=? OR `to`=?", addr, addr)
 	}
 	if types != "" {
-		db.Joins("LEFT JOIN erbies ON hash=tx_hash").Where("`type` IN (?)", strings.Split(types, ","))
+		db.Joins("LEFT JOIN erbies ON hash=tx_hash").Where("erbies.`type` IN (?)", strings.Split(types, ","))
 	}
 	if number != "" || addr != "" || types != "" {
-		err = db.Count(&res.Total).Error
+		// the pages after a cursor are not counted again
+		if cursor == "" {
+			err = db.Count(&res.Total).Error
+		}
 	} else {
 		// use stats to speed up queries
 		res.Total = stats.TotalTransaction
@@ -36,39 +59,132 @@ func FetchTransactions(page, size int, number, addr, types string) (res Transact
 	if err != nil {
 		return
 	}
-	err = db.Order("block_number DESC").Offset((page - 1) * size).Limit(size).Find(&res.Transactions).Error
+	if db, err = paginate(db, page, size, cursor, "transactions.block_number", "transactions.tx_index"); err != nil {
+		return
+	}
+	if err = db.Find(&res.Transactions).Error; err != nil {
+		return
+	}
+	for _, tx := range res.Transactions {
+		tx.Confirmations, tx.Finalized = confirm(tx.BlockNumber)
+		setMethod(tx)
+	}
+	if n := len(res.Transactions); n > 0 {
+		res.Next = nextCursor(size, n, res.Transactions[n-1].BlockNumber, res.Transactions[n-1].TxIndex)
+	}
 	return
 }
 
-func GetTransactionLogs(hash string) (t []model.EventLog, err error) {
-	err = DB.Where("tx_hash=?", hash).Find(&t).Error
+// EventLogRes event log with its decoded parameters
+type EventLogRes struct {
+	model.EventLog
+	Decoded *utils.ABIDecoded `json:"decoded,omitempty"` //event and parameters, with the ABI of the emitting contract
+}
+
+func GetTransactionLogs(hash string) (t []*EventLogRes, err error) {
+	if err = DB.Model(&model.EventLog{}).Where("tx_hash=?", hash).Find(&t).Error; err != nil {
+		return
+	}
+	for _, log := range t {
+		log.Decoded = decodeLog(&log.EventLog)
+		if setEvent(&log.EventLog); log.Decoded != nil {
+			log.Event, log.EventCandidates = &log.Decoded.Signature, nil
+		}
+	}
 	return
 }
 
 // InternalTxsRes internal transaction paging return parameters
 type InternalTxsRes struct {
-	Total       int64               `json:"total"`        //The total number
-	InternalTxs []*model.InternalTx `json:"internal_txs"` //transaction list
+	Total       int64               `json:"total"`          //The total number
+	InternalTxs []*model.InternalTx `json:"internal_txs"`   //transaction list
+	Next        string              `json:"next,omitempty"` //cursor of the next page, empty on the last page
 }
 
-func GetInternalTransactions(page, size int) (res InternalTxsRes, err error) {
-	err = DB.Order("`block_number` DESC").Offset((page - 1) * size).Limit(size).Find(&res.InternalTxs).Error
+func GetInternalTransactions(page, size int, cursor string) (res InternalTxsRes, err error) {
+	db, err := paginate(DB, page, size, cursor, "`block_number`", "`tx_hash`", "`index`")
+	if err != nil {
+		return
+	}
+	err = db.Find(&res.InternalTxs).Error
 	res.Total = stats.TotalInternalTx
+	if n := len(res.InternalTxs); n > 0 {
+		last := res.InternalTxs[n-1]
+		res.Next = nextCursor(size, n, last.BlockNumber, last.TxHash, last.Index)
+	}
 	return
 }
 
 func GetInternalTransaction(hash string) (res []*model.InternalTx, err error) {
-	err = DB.Where("`tx_hash`=?", hash).Find(&res).Error
+	err = DB.Where("`tx_hash`=?", hash).Order("`index`").Find(&res).Error
+	return
+}
+
+// CallFrame a call of the transaction and the calls it made
+type CallFrame struct {
+	Index   types.Long    `json:"index"`           //call index, -1: the transaction
+	Op      string        `json:"op"`              //Operation
+	From    types.Address `json:"from"`            //Originating address
+	To      types.Address `json:"to"`              //Receive address, the created contract address of a creation
+	Value   types.BigInt  `json:"value"`           //Amount, unit wei
+	Gas     types.Long    `json:"gas"`             //Gas
+	GasUsed types.Long    `json:"gasUsed"`         //Gas consumption
+	Input   string        `json:"input"`           //call data
+	Output  string        `json:"output"`          //return data
+	Error   *string       `json:"error,omitempty"` //exec error
+	Calls   []*CallFrame  `json:"calls,omitempty"` //calls made by this call
+}
+
+// GetInternalTransactionTree returns the call tree of the transaction, the root is the transaction itself
+func GetInternalTransactionTree(hash string) (root *CallFrame, err error) {
+	var tx model.Transaction
+	if err = DB.Where("`hash`=?", hash).Take(&tx).Error; err != nil {
+		return
+	}
+	root = &CallFrame{Index: -1, Op: "CALL", From: tx.From, Value: tx.Value, Gas: tx.Gas, GasUsed: tx.GasUsed, Input: tx.Input, Error: tx.Error}
+	if tx.To != nil {
+		root.To = *tx.To
+	} else if tx.ContractAddress != nil {
+		root.Op, root.To = "CREATE", *tx.ContractAddress
+	}
+	internalTxs, err := GetInternalTransaction(hash)
+	if err != nil {
+		return
+	}
+	frames := make(map[types.Long]*CallFrame, len(internalTxs))
+	for _, internalTx := range internalTxs {
+		frame := &CallFrame{
+			Index:   internalTx.Index,
+			Op:      internalTx.Op,
+			From:    internalTx.From,
+			To:      internalTx.To,
+			Value:   internalTx.Value,
+			Gas:     internalTx.Gas,
+			GasUsed: internalTx.GasUsed,
+			Input:   internalTx.Input,
+			Output:  internalTx.Output,
+			Error:   internalTx.Error,
+		}
+		frames[frame.Index] = frame
+		// the calls are stored in call order, so the parent is always found before its calls,
+		// the calls indexed before the call tree was kept have no depth and are attached to the transaction
+		if parent := frames[internalTx.ParentIndex]; internalTx.Depth > 1 && parent != nil {
+			parent.Calls = append(parent.Calls, frame)
+		} else {
+			root.Calls = append(root.Calls, frame)
+		}
+	}
 	return
 }
 
 // ErbiesRes erbie transaction paging return parameters
 type ErbiesRes struct {
-	Total int64          `json:"total"` //The total number of transactions
-	Data  []*model.Erbie `json:"data"`  //erbie transaction list
+	Total int64          `json:"total"`          //The total number of transactions, not counted on the pages after a cursor
+	Data  []*model.Erbie `json:"data"`           //erbie transaction list
+	Next  string         `json:"next,omitempty"` //cursor of the next page, empty on the last page
 }
 
-func FetchErbieTxs(page, size int, number, address, epoch, account, types string) (res ErbiesRes, err error) {
+func FetchErbieTxs(page, size int, cursor, number, address, epoch, account, types string) (res ErbiesRes, err error) {
 	db := DB.Model(&model.Erbie{})
 	if number != "" {
 		db = db.Where("`block_number`=?", number)
@@ -77,7 +193,7 @@ func FetchErbieTxs(page, size int, number, address, epoch, account, types string
 		db = db.Where("`address`=?", address)
 	}
 	if epoch != "" {
-		db = db.Where("LEFT(address,39)=?", epoch)
+		db = db.Where("SUBSTR(address,1,39)=?", epoch)
 	}
 	if account != "" {
 		db = db.Where("`from`=? OR `to`=?", account, account)
@@ -86,10 +202,21 @@ func FetchErbieTxs(page, size int, number, address, epoch, account, types string
 		db = db.Where("`type` IN (?)", strings.Split(types, ","))
 	}
 
-	if err = db.Count(&res.Total).Error; err != nil {
+	// the pages after a cursor are not counted again
+	if cursor == "" {
+		if err = db.Count(&res.Total).Error; err != nil {
+			return
+		}
+	}
+	if db, err = paginate(db, page, size, cursor, "`block_number`", "`tx_hash`"); err != nil {
 		return
 	}
-	err = db.Offset((page - 1) * size).Limit(size).Find(&res.Data).Error
+	if err = db.Find(&res.Data).Error; err != nil {
+		return
+	}
+	if n := len(res.Data); n > 0 {
+		res.Next = nextCursor(size, n, res.Data[n-1].BlockNumber, res.Data[n-1].TxHash)
+	}
 	return
 }
 
@@ -97,3 +224,10 @@ func GetErbieTransaction(hash string) (res model.Erbie, err error) {
 	err = DB.Where("`tx_hash`=?", hash).Take(&res).Error
 	return
 }
+
+// initInternalTxs sets the block number of the internal transactions stored before it was recorded
+func initInternalTxs(db *gorm.DB) error {
+	from := " FROM transactions x WHERE x.hash=internal_txes.tx_hash"
+	return db.Exec("UPDATE internal_txes SET block_number=(SELECT x.block_number" + from + ") " +
+		"WHERE block_number=0 AND EXISTS (SELECT 1" + from + ")").Error
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"gorm.io/gorm"
+	"server/common/model"
+	"server/common/types"
+	"server/common/utils"
+)
+
+// TransferFilter filters of the token transfer queries, the empty ones match all the transfers
+type TransferFilter struct {
+	Address   string //token contract address
+	From      string //sender address
+	To        string //receiver address
+	Addr      string //sender or receiver address
+	TxHash    string //transaction hash
+	FromBlock string //first block number
+	ToBlock   string //last block number
+}
+
+func (f *TransferFilter) apply(db *gorm.DB) *gorm.DB {
+	if f.Address != "" {
+		db = db.Where("address=?", f.Address)
+	}
+	if f.From != "" {
+		db = db.Where("`from`=?", f.From)
+	}
+	if f.To != "" {
+		db = db.Where("`to`=?", f.To)
+	}
+	if f.Addr != "" {
+		db = db.Where("`from`=? OR `to`=?", f.Addr, f.Addr)
+	}
+	if f.TxHash != "" {
+		db = db.Where("tx_hash=?", f.TxHash)
+	}
+	if f.FromBlock != "" {
+		db = db.Where("block_number>=?", f.FromBlock)
+	}
+	if f.ToBlock != "" {
+		db = db.Where("block_number<=?", f.ToBlock)
+	}
+	return db
+}
+
+// ERC20TransfersRes ERC20 transfer paging return parameters
+type ERC20TransfersRes struct {
+	Total     int64                  `json:"total"`          //The total number of transfers, not counted on the pages after a cursor
+	Transfers []*model.ERC20Transfer `json:"transfers"`      //transfer list, the latest first
+	Next      string                 `json:"next,omitempty"` //cursor of the next page, empty on the last page
+}
+
+func FetchERC20Transfers(page, size int, cursor string, filter TransferFilter) (res ERC20TransfersRes, err error) {
+	db := filter.apply(DB.Model(&model.ERC20Transfer{}))
+	// the pages after a cursor are not counted again
+	if cursor == "" {
+		if err = db.Count(&res.Total).Error; err != nil {
+			return
+		}
+	}
+	if db, err = paginate(db, page, size, cursor, "block_number", "log_index"); err != nil {
+		return
+	}
+	if err = db.Find(&res.Transfers).Error; err != nil {
+		return
+	}
+	if n := len(res.Transfers); n > 0 {
+		last := res.Transfers[n-1]
+		res.Next = nextCursor(size, n, last.BlockNumber, last.LogIndex)
+	}
+	return
+}
+
+// ERC721TransfersRes ERC721 transfer paging return parameters
+type ERC721TransfersRes struct {
+	Total     int64                   `json:"total"`          //The total number of transfers, not counted on the pages after a cursor
+	Transfers []*model.ERC721Transfer `json:"transfers"`      //transfer list, the latest first
+	Next      string                  `json:"next,omitempty"` //cursor of the next page, empty on the last page
+}
+
+func FetchERC721Transfers(page, size int, cursor string, filter TransferFilter) (res ERC721TransfersRes, err error) {
+	db := filter.apply(DB.Model(&model.ERC721Transfer{}))
+	// the pages after a cursor are not counted again
+	if cursor == "" {
+		if err = db.Count(&res.Total).Error; err != nil {
+			return
+		}
+	}
+	if db, err = paginate(db, page, size, cursor, "block_number", "log_index"); err != nil {
+		return
+	}
+	if err = db.Find(&res.Transfers).Error; err != nil {
+		return
+	}
+	if n := len(res.Transfers); n > 0 {
+		last := res.Transfers[n-1]
+		res.Next = nextCursor(size, n, last.BlockNumber, last.LogIndex)
+	}
+	return
+}
+
+// ERC1155TransfersRes ERC1155 transfer paging return parameters
+type ERC1155TransfersRes struct {
+	Total     int64                    `json:"total"`          //The total number of transfers, not counted on the pages after a cursor
+	Transfers []*model.ERC1155Transfer `json:"transfers"`      //transfer list, the latest first
+	Next      string                   `json:"next,omitempty"` //cursor of the next page, empty on the last page
+}
+
+func FetchERC1155Transfers(page, size int, cursor string, filter TransferFilter) (res ERC1155TransfersRes, err error) {
+	db := filter.apply(DB.Model(&model.ERC1155Transfer{}))
+	// the pages after a cursor are not counted again
+	if cursor == "" {
+		if err = db.Count(&res.Total).Error; err != nil {
+			return
+		}
+	}
+	if db, err = paginate(db, page, size, cursor, "block_number", "log_index", "batch_index"); err != nil {
+		return
+	}
+	if err = db.Find(&res.Transfers).Error; err != nil {
+		return
+	}
+	if n := len(res.Transfers); n > 0 {
+		last := res.Transfers[n-1]
+		res.Next = nextCursor(size, n, last.BlockNumber, last.LogIndex, last.BatchIndex)
+	}
+	return
+}
+
+// TokenTransfers token transfers of a transaction
+type TokenTransfers struct {
+	ERC20   []*model.ERC20Transfer   `json:"erc20"`   //ERC20 transfers
+	ERC721  []*model.ERC721Transfer  `json:"erc721"`  //ERC721 transfers
+	ERC1155 []*model.ERC1155Transfer `json:"erc1155"` //ERC1155 transfers
+}
+
+func getTokenTransfers(hash string) (res TokenTransfers, err error) {
+	if err = DB.Where("tx_hash=?", hash).Order("log_index").Find(&res.ERC20).Error; err != nil {
+		return
+	}
+	if err = DB.Where("tx_hash=?", hash).Order("log_index").Find(&res.ERC721).Error; err != nil {
+		return
+	}
+	err = DB.Where("tx_hash=?", hash).Order("log_index, batch_index").Find(&res.ERC1155).Error
+	return
+}
+
+// transferTables the tables of the token transfers and their models
+var transferTables = map[string]any{
+	"erc20_transfers":   &model.ERC20Transfer{},
+	"erc721_transfers":  &model.ERC721Transfer{},
+	"erc1155_transfers": &model.ERC1155Transfer{},
+}
+
+// transferIndexMigration backfills the log index and the batch index of the transfers stored before they were recorded
+const transferIndexMigration = "transfer_log_index"
+
+// initTransfers sets the block number, timestamp, log index and batch index of the transfers stored before they were recorded
+func initTransfers(db *gorm.DB) (err error) {
+	for table := range transferTables {
+		from := " FROM transactions x WHERE x.hash=" + table + ".tx_hash"
+		err = db.Exec("UPDATE " + table + " SET block_number=(SELECT x.block_number" + from + "), timestamp=(SELECT x.timestamp" + from + ") " +
+			"WHERE block_number=0 AND EXISTS (SELECT 1" + from + ")").Error
+		if err != nil {
+			return
+		}
+	}
+	var done bool
+	err = db.Model(&model.Migration{}).Select("COUNT(*)>0").Where("name=?", transferIndexMigration).Scan(&done).Error
+	if err != nil || done {
+		return
+	}
+	for table, transfer := range transferTables {
+		// the log index of these transfers was left 0 and the transfers of a batch shared their position, the
+		// transactions with such rows are parsed again and every row takes the index of the log it matches
+		keys := "tx_hash, log_index"
+		if _, ok := transfer.(*model.ERC1155Transfer); ok {
+			keys += ", batch_index"
+		}
+		var hashes []types.Hash
+		err = db.Table(table).Distinct("tx_hash").Where("log_index=0 OR tx_hash IN (SELECT tx_hash FROM "+table+
+			" GROUP BY "+keys+" HAVING COUNT(*)>1)").Pluck("tx_hash", &hashes).Error
+		if err != nil {
+			return
+		}
+		for len(hashes) > 0 {
+			batch := hashes
+			if len(batch) > 500 {
+				batch = batch[:500]
+			}
+			if err = reparseTransfers(db, transfer, batch); err != nil {
+				return
+			}
+			hashes = hashes[len(batch):]
+		}
+	}
+	return db.Create(&model.Migration{Name: transferIndexMigration, Timestamp: types.Long(time.Now().Unix())}).Error
+}
+
+// reparseTransfers sets the log index and the batch index of the transfers of the transactions from their logs,
+// every stored transfer takes the indexes of a parsed one with the same content, the unmatched ones are kept
+func reparseTransfers(db *gorm.DB, transfer any, hashes []types.Hash) (err error) {
+	var logs []*model.EventLog
+	if err = db.Where("tx_hash IN ?", hashes).Order("tx_hash, `index`").Find(&logs).Error; err != nil {
+		return
+	}
+	kind := reflect.TypeOf(transfer)
+	stored := reflect.New(reflect.SliceOf(kind))
+	if err = db.Where("tx_hash IN ?", hashes).Find(stored.Interface()).Error; err != nil {
+		return
+	}
+	parsed := make(map[string][]any)
+	for _, log := range logs {
+		for _, t := range utils.UnpackTransferLog(log) {
+			if reflect.TypeOf(t) == kind {
+				key := transferKey(t)
+				parsed[key] = append(parsed[key], t)
+			}
+		}
+	}
+	transfers := stored.Elem()
+	for i := 0; i < transfers.Len(); i++ {
+		t := transfers.Index(i).Interface()
+		key := transferKey(t)
+		if len(parsed[key]) == 0 {
+			continue
+		}
+		match := reflect.ValueOf(parsed[key][0]).Elem()
+		parsed[key] = parsed[key][1:]
+		row := reflect.ValueOf(t).Elem()
+		for _, field := range []string{"LogIndex", "BatchIndex"} {
+			if f := row.FieldByName(field); f.IsValid() {
+				f.Set(match.FieldByName(field))
+			}
+		}
+	}
+	if transfers.Len() == 0 {
+		return
+	}
+	return db.Transaction(func(db *gorm.DB) error {
+		if err := db.Where("tx_hash IN ?", hashes).Delete(transfer).Error; err != nil {
+			return err
+		}
+		return db.CreateInBatches(transfers.Interface(), 1000).Error
+	})
+}
+
+// transferKey identifies the transfer by its transaction and content, without its position in the block
+func transferKey(transfer any) string {
+	key := reflect.New(reflect.TypeOf(transfer).Elem()).Elem()
+	key.Set(reflect.ValueOf(transfer).Elem())
+	for _, field := range []string{"BlockNumber", "LogIndex", "BatchIndex", "Timestamp"} {
+		if f := key.FieldByName(field); f.IsValid() {
+			f.SetInt(0)
+		}
+	}
+	return fmt.Sprintf("%+v", key.Interface())
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"testing"
+
+	"server/common/model"
+	"server/common/types"
+	"server/common/utils"
+)
+
+const (
+	testToken    = types.Address("0x00000000000000000000000000000000000000aa")
+	testTransfer = types.Hash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	testBatch    = types.Hash("0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb")
+	testWord     = "000000000000000000000000000000000000000000000000000000000000000"
+)
+
+func TestInitTransfers(t *testing.T) {
+	openTestDB(t)
+	from, to := types.Hash("0x"+testWord+"1"), types.Hash("0x"+testWord+"2")
+	logs := []*model.EventLog{
+		// the first transaction of the block has the log with index 0
+		{TxHash: "0x01", BlockNumber: 5, Index: 0, Address: testToken, Topics: []types.Hash{testTransfer, from, to}, Data: "0x" + testWord + "1"},
+		{TxHash: "0x01", BlockNumber: 5, Index: 1, Address: testToken, Topics: []types.Hash{testTransfer, from, to}, Data: "0x" + testWord + "2"},
+		{TxHash: "0x02", BlockNumber: 5, Index: 2, Address: testToken, Topics: []types.Hash{testTransfer, from, to}, Data: "0x" + testWord + "1"},
+		// a batch moving the same token twice
+		{TxHash: "0x03", BlockNumber: 5, Index: 3, Address: testToken, Topics: []types.Hash{testBatch, from, from, to},
+			Data: "0x" + testWord[1:] + "40" + testWord[1:] + "a0" + testWord + "2" + testWord + "5" + testWord + "5" + testWord + "2" + testWord + "7" + testWord + "7"},
+	}
+	if err := DB.Create(logs).Error; err != nil {
+		t.Fatal(err)
+	}
+	// the transfers as the older versions stored them, the one without a log is kept as it is
+	var erc20 []*model.ERC20Transfer
+	var erc1155 []*model.ERC1155Transfer
+	for _, log := range logs {
+		for _, transfer := range utils.UnpackTransferLog(log) {
+			switch transfer := transfer.(type) {
+			case *model.ERC20Transfer:
+				transfer.LogIndex = 0
+				erc20 = append(erc20, transfer)
+			case *model.ERC1155Transfer:
+				transfer.LogIndex, transfer.BatchIndex = 0, 0
+				erc1155 = append(erc1155, transfer)
+			}
+		}
+	}
+	erc20 = append(erc20, &model.ERC20Transfer{TxHash: "0x02", BlockNumber: 5, Address: testToken, Value: "9"})
+	if err := DB.Create(erc20).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := DB.Create(erc1155).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := initTransfers(DB); err != nil {
+		t.Fatal(err)
+	}
+	var indexes []types.Long
+	DB.Model(&model.ERC20Transfer{}).Order("tx_hash, log_index, value").Pluck("log_index", &indexes)
+	if len(indexes) != 4 || indexes[0] != 0 || indexes[1] != 1 || indexes[2] != 0 || indexes[3] != 2 {
+		t.Fatal("ERC20 log indexes", indexes)
+	}
+	DB.Model(&model.ERC1155Transfer{}).Order("batch_index").Pluck("batch_index", &indexes)
+	if len(indexes) != 2 || indexes[0] != 0 || indexes[1] != 1 {
+		t.Fatal("ERC1155 batch indexes", indexes)
+	}
+	DB.Model(&model.ERC1155Transfer{}).Distinct("log_index").Pluck("log_index", &indexes)
+	if len(indexes) != 1 || indexes[0] != 3 {
+		t.Fatal("ERC1155 log indexes", indexes)
+	}
+	// the migration is done once
+	if err := DB.Model(&model.ERC20Transfer{}).Where("value='2'").Update("log_index", 0).Error; err != nil {
+		t.Fatal(err)
+	}
+	if err := initTransfers(DB); err != nil {
+		t.Fatal(err)
+	}
+	var count int64
+	if DB.Model(&model.ERC20Transfer{}).Where("log_index=0").Count(&count); count != 3 {
+		t.Fatal("the migration ran again", count)
+	}
+}
+
+func TestTransferCursor(t *testing.T) {
+	openTestDB(t)
+	// a batch repeating a token at the page boundaries, then single transfers
+	var transfers []*model.ERC1155Transfer
+	for i := 0; i < 5; i++ {
+		transfers = append(transfers, &model.ERC1155Transfer{TxHash: "0x01", BlockNumber: 7, LogIndex: 1, BatchIndex: types.Long(i),
+			Address: testToken, TokenId: "5", Value: "1"})
+	}
+	for i := 0; i < 3; i++ {
+		transfers = append(transfers, &model.ERC1155Transfer{TxHash: "0x02", BlockNumber: types.Long(8 + i), Address: testToken, TokenId: "5", Value: "1"})
+	}
+	if err := DB.Create(transfers).Error; err != nil {
+		t.Fatal(err)
+	}
+	for _, size := range []int{1, 2, 4, 8, 10} {
+		seen, cursor, pages := make(map[[2]types.Long]bool), "", 0
+		for {
+			res, err := FetchERC1155Transfers(1, size, cursor, TransferFilter{Address: string(testToken)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := int64(len(transfers)); cursor == "" && res.Total != want || cursor != "" && res.Total != 0 {
+				t.Fatalf("size %v page %v: total %v", size, pages, res.Total)
+			}
+			for _, transfer := range res.Transfers {
+				key := [2]types.Long{transfer.BlockNumber, transfer.BatchIndex}
+				if seen[key] {
+					t.Fatalf("size %v: transfer %v repeated", size, key)
+				}
+				seen[key] = true
+			}
+			if pages++; res.Next == "" {
+				break
+			}
+			if len(res.Transfers) != size {
+				t.Fatalf("size %v: page %v has %v transfers and a next cursor", size, pages, len(res.Transfers))
+			}
+			cursor = res.Next
+		}
+		// a full last page still gives a cursor, followed by an empty page
+		if want := len(transfers)/size + 1; len(seen) != len(transfers) || pages != want {
+			t.Fatalf("size %v: %v transfers in %v pages, want %v pages", size, len(seen), pages, want)
+		}
+	}
+	if _, err := FetchERC1155Transfers(1, 2, "bad", TransferFilter{}); err == nil {
+		t.Fatal("bad cursor accepted")
+	}
+}
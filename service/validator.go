@@ -2,6 +2,7 @@ package service
 
 import (
 	"bufio"
+	"context"
 	"log"
 	"os"
 	"strings"
@@ -12,7 +13,9 @@ import (
 	"server/common/utils"
 )
 
-func initValidator(db *gorm.DB) error {
+// WatchValidator follows the node logs of the validators and updates their location and last message until the
+// context is cancelled
+func WatchValidator(ctx context.Context) error {
 	addLogFile := utils.ExpandPath("~/ops/log_add_node.log")
 	msgLogFile := utils.ExpandPath("~/ops/log_com.log")
 	os.MkdirAll(utils.ExpandPath("~/ops"), os.ModePerm)
@@ -22,23 +25,23 @@ func initValidator(db *gorm.DB) error {
 	if err != nil {
 		return err
 	}
-	go func() {
-		lastLine, lastSize := updateLocation(db, addLogFile, 0, 0)
-		updateLastMsg(db, msgLogFile)
-		for {
-			select {
-			case event := <-w.Events:
-				if event.Name == addLogFile {
-					lastLine, lastSize = updateLocation(db, addLogFile, lastLine, lastSize)
-				} else if event.Name == msgLogFile {
-					updateLastMsg(db, msgLogFile)
-				}
-			case err := <-w.Errors:
-				log.Printf("validator,file watcher error: %v\n", err)
+	defer w.Close()
+	lastLine, lastSize := updateLocation(DB, addLogFile, 0, 0)
+	updateLastMsg(DB, msgLogFile)
+	for {
+		select {
+		case event := <-w.Events:
+			if event.Name == addLogFile {
+				lastLine, lastSize = updateLocation(DB, addLogFile, lastLine, lastSize)
+			} else if event.Name == msgLogFile {
+				updateLastMsg(DB, msgLogFile)
 			}
+		case err := <-w.Errors:
+			log.Printf("validator,file watcher error: %v\n", err)
+		case <-ctx.Done():
+			return nil
 		}
-	}()
-	return nil
+	}
 }
 
 func updateLocation(db *gorm.DB, fileName string, lastLine, lastSize int64) (int64, int64) {
@@ -58,6 +61,7 @@ func updateLocation(db *gorm.DB, fileName string, lastLine, lastSize int64) (int
 							if ip := splits[2]; ip != "127.0.0.1" && ip != "0.0.0.0" {
 								countryName, cityName, latitude, longitude := utils.IP2Location(ip)
 								db.Clauses(clause.OnConflict{
+									Columns:   []clause.Column{{Name: "address"}},
 									DoUpdates: clause.AssignmentColumns([]string{"ip", "latitude", "longitude", "city", "country"}),
 								}).Create(&model.Location{
 									Address:   strings.ToLower(splits[1]),
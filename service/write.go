@@ -1,9 +1,13 @@
 package service
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"math"
 	"strconv"
+	"time"
 
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
@@ -13,6 +17,9 @@ import (
 
 func Insert(parsed *model.Parsed) (head types.Long, err error) {
 	err = DB.Transaction(func(db *gorm.DB) (err error) {
+		if err = lockWrites(db); err != nil {
+			return
+		}
 		err = db.Model(&model.Block{}).Where("`hash`=?", parsed.ParentHash).Select("`number`+1").Scan(&head).Error
 		if err != nil || parsed.Number != head {
 			return
@@ -41,6 +48,19 @@ func Insert(parsed *model.Parsed) (head types.Long, err error) {
 				return
 			}
 		}
+		// update the token balances, counts and the ERC721 owners, write the created tokens
+		if err = saveBalances(db, parsed.CacheTransferLogs, 1); err != nil {
+			return
+		}
+		if err = saveInventory(db, parsed); err != nil {
+			return
+		}
+		if err = saveTokens(db, parsed); err != nil {
+			return
+		}
+		if err = saveProxies(db, parsed); err != nil {
+			return
+		}
 		// write account information
 		if len(parsed.CacheAccounts) > 0 {
 			//if err = db.Clauses(clause.OnConflict{
@@ -91,6 +111,7 @@ func InsertAccounts(db *gorm.DB, accounts []*model.Account) error {
 	accountsLen := len(accounts)
 	if accountsLen <= insertNum {
 		if err := db.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "address"}},
 			DoUpdates: clause.AssignmentColumns([]string{"balance", "nonce", "number", "snft_value"}),
 		}).Create(accounts).Error; err != nil {
 			return err
@@ -111,6 +132,7 @@ func InsertAccounts(db *gorm.DB, accounts []*model.Account) error {
 			}
 
 			if err := db.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "address"}},
 				DoUpdates: clause.AssignmentColumns([]string{"balance", "nonce", "number", "snft_value"}),
 			}).Create(batchAccounts).Error; err != nil {
 				return err
@@ -135,61 +157,267 @@ func VerifyHead(parsed *model.Parsed) (pass bool, err error) {
 }
 
 func SetHead(parsed *model.Parsed) error {
+	return DB.Transaction(func(db *gorm.DB) error {
+		return setHead(db, parsed)
+	})
+}
+
+// Fork rolls the stored chain back to the fork block parsed.Number like SetHead, and records the reorg found by
+// the block newHead of the new chain. The transactions of the orphaned blocks are kept in orphaned_txs
+func Fork(parsed *model.Parsed, newHead *model.Header) error {
 	return DB.Transaction(func(db *gorm.DB) (err error) {
-		if head := parsed.Number; head >= 0 {
-			if err = db.Delete(&model.Epoch{}, "start_number>?", head).Error; err != nil {
-				return
-			}
-			if err = db.Delete(&model.Staker{}, "block_number>?", head).Error; err != nil {
-				return
-			}
-			hashes := db.Model(&model.Transaction{}).Select("hash").Where("block_number>?", head)
-			if err = db.Delete(&model.Account{}, "created_tx IN (?)", hashes).Error; err != nil {
-				return
-			}
-			if err = db.Delete(&model.ERC20Transfer{}, "tx_hash IN (?)", hashes).Error; err != nil {
-				return
-			}
-			if err = db.Delete(&model.ERC721Transfer{}, "tx_hash IN (?)", hashes).Error; err != nil {
-				return
-			}
-			if err = db.Delete(&model.ERC1155Transfer{}, "tx_hash IN (?)", hashes).Error; err != nil {
-				return
-			}
-			if err = db.Delete(&model.InternalTx{}, "`tx_hash` IN (?)", hashes).Error; err != nil {
-				return
-			}
-			if err = db.Delete(&model.EventLog{}, "block_number>?", head).Error; err != nil {
-				return
-			}
-			if err = db.Delete(&model.Erbie{}, "block_number>?", head).Error; err != nil {
+		if parsed.Number < 0 {
+			return setHead(db, parsed)
+		}
+		var orphaned []*model.Block
+		if err = db.Select("hash", "number").Where("number>?", parsed.Number).Order("number").Find(&orphaned).Error; err != nil {
+			return
+		}
+		reorg := model.Reorg{
+			ForkNumber: parsed.Number,
+			OldHead:    parsed.Number,
+			NewHead:    newHead.Number,
+			NewHash:    newHead.Hash,
+			Depth:      types.Long(len(orphaned)),
+			Blocks:     make([]types.Hash, len(orphaned)),
+			Timestamp:  types.Long(time.Now().Unix()),
+		}
+		for i, block := range orphaned {
+			reorg.Blocks[i] = block.Hash
+			reorg.OldHead, reorg.OldHash = block.Number, block.Hash
+		}
+		var txs []*model.Transaction
+		if err = db.Where("block_number>?", parsed.Number).Find(&txs).Error; err != nil {
+			return
+		}
+		reorg.TxCount = int64(len(txs))
+		if err = db.Create(&reorg).Error; err != nil {
+			return
+		}
+		orphanedTxs := make([]*model.OrphanedTx, len(txs))
+		for i, tx := range txs {
+			orphanedTxs[i] = &model.OrphanedTx{ReorgID: reorg.ID, Transaction: *tx}
+		}
+		if err = db.CreateInBatches(orphanedTxs, 1000).Error; err != nil {
+			return
+		}
+		log.Printf("reorg %v: blocks %v..%v (%v) orphaned by %v (%v), %v transactions\n", reorg.ID,
+			parsed.Number+1, reorg.OldHead, reorg.OldHash, reorg.NewHead, reorg.NewHash, reorg.TxCount)
+		return setHead(db, parsed)
+	})
+}
+
+func setHead(db *gorm.DB, parsed *model.Parsed) (err error) {
+	if err = lockWrites(db); err != nil {
+		return
+	}
+	if head := parsed.Number; head >= 0 {
+		if err = db.Delete(&model.Epoch{}, "start_number>?", head).Error; err != nil {
+			return
+		}
+		if err = db.Delete(&model.Staker{}, "block_number>?", head).Error; err != nil {
+			return
+		}
+		hashes := db.Model(&model.Transaction{}).Select("hash").Where("block_number>?", head)
+		if err = db.Delete(&model.Account{}, "created_tx IN (?)", hashes).Error; err != nil {
+			return
+		}
+		if err = db.Delete(&model.NFT{}, "block_number>?", head).Error; err != nil {
+			return
+		}
+		if err = db.Delete(&model.SNFT{}, "reward_number>?", head).Error; err != nil {
+			return
+		}
+		if err = db.Delete(&model.Token{}, "block_number>?", head).Error; err != nil {
+			return
+		}
+		// the token balances, counts and the ERC721 owners lose the rolled back transfers, the total supply stays as read at the
+		// rolled back blocks until the token is transferred again
+		if err = revertTransfers(db, head+1, math.MaxInt64); err != nil {
+			return
+		}
+		var nfts []*model.ERC721Token
+		if nfts, err = inventoryTokens(db, head+1, math.MaxInt64); err != nil {
+			return
+		}
+		if err = rollback(db, head+1, math.MaxInt64); err != nil {
+			return
+		}
+		if err = rebuildInventory(db, nfts); err != nil {
+			return
+		}
+		return fixStats(db, parsed)
+	} else {
+		if err = model.ClearTable(db); err != nil {
+			return
+		}
+		return initStats(db)
+	}
+}
+
+// rollback deletes the rows recorded per block for the blocks from..to, the state accumulated over the blocks
+// (accounts, NFT, SNFT, stakers, validators) is left to the caller
+func rollback(db *gorm.DB, from, to types.Long) (err error) {
+	if err = db.Delete(&model.ERC20Transfer{}, "block_number BETWEEN ? AND ?", from, to).Error; err != nil {
+		return
+	}
+	if err = db.Delete(&model.ERC721Transfer{}, "block_number BETWEEN ? AND ?", from, to).Error; err != nil {
+		return
+	}
+	if err = db.Delete(&model.ERC1155Transfer{}, "block_number BETWEEN ? AND ?", from, to).Error; err != nil {
+		return
+	}
+	hashes := db.Model(&model.Transaction{}).Select("hash").Where("block_number BETWEEN ? AND ?", from, to)
+	if err = db.Delete(&model.InternalTx{}, "`tx_hash` IN (?)", hashes).Error; err != nil {
+		return
+	}
+	if err = db.Delete(&model.EventLog{}, "block_number BETWEEN ? AND ?", from, to).Error; err != nil {
+		return
+	}
+	if err = db.Delete(&model.ProxyImplementation{}, "block_number BETWEEN ? AND ?", from, to).Error; err != nil {
+		return
+	}
+	if err = db.Delete(&model.Erbie{}, "block_number BETWEEN ? AND ?", from, to).Error; err != nil {
+		return
+	}
+	if err = db.Delete(&model.Reward{}, "block_number BETWEEN ? AND ?", from, to).Error; err != nil {
+		return
+	}
+	if err = db.Delete(&model.Slashing{}, "block_number BETWEEN ? AND ?", from, to).Error; err != nil {
+		return
+	}
+	if err = db.Delete(&model.Transaction{}, "block_number BETWEEN ? AND ?", from, to).Error; err != nil {
+		return
+	}
+	return db.Delete(&model.Block{}, "number BETWEEN ? AND ?", from, to).Error
+}
+
+// lockWrites locks the stats rows until the transaction ends, so that the transactions writing the blocks run one
+// at a time: the token balances and the inventory are read, changed and written back, Insert and a Reinsert running
+// in another process would lose one of the changes. SQLite fails the second writer instead
+func lockWrites(db *gorm.DB) error {
+	if dialect == dialectSQLite {
+		return nil
+	}
+	var ids []int64
+	return db.Model(&model.Stats{}).Clauses(clause.Locking{Strength: "UPDATE"}).Pluck("chain_id", &ids).Error
+}
+
+// Reinsert replaces the stored rows of a block in the middle of the chain with the parsed ones, in one transaction.
+// Only the rows recorded per block are written again, the accumulated state is kept as the later blocks changed it,
+// call RefreshStats once the blocks are written
+func Reinsert(parsed *model.Parsed) error {
+	return DB.Transaction(func(db *gorm.DB) (err error) {
+		if err = lockWrites(db); err != nil {
+			return
+		}
+		var stored types.Hash
+		if err = db.Model(&model.Block{}).Where("`number`=?", parsed.Number).Select("`hash`").Scan(&stored).Error; err != nil {
+			return
+		}
+		if stored != parsed.Hash {
+			return fmt.Errorf("stored block %v is %v, not %v", parsed.Number, stored, parsed.Hash)
+		}
+		if err = revertTransfers(db, parsed.Number, parsed.Number); err != nil {
+			return
+		}
+		var nfts []*model.ERC721Token
+		if nfts, err = inventoryTokens(db, parsed.Number, parsed.Number); err != nil {
+			return
+		}
+		if err = rollback(db, parsed.Number, parsed.Number); err != nil {
+			return
+		}
+		if len(parsed.CacheTxs) > 0 {
+			if err = db.Create(parsed.CacheTxs).Error; err != nil {
 				return
 			}
-			if err = db.Delete(&model.NFT{}, "block_number>?", head).Error; err != nil {
+		}
+		if len(parsed.CacheLogs) > 0 {
+			if err = db.Create(parsed.CacheLogs).Error; err != nil {
 				return
 			}
-			if err = db.Delete(&model.SNFT{}, "reward_number>?", head).Error; err != nil {
+		}
+		if len(parsed.CacheInternalTxs) > 0 {
+			if err = db.Create(parsed.CacheInternalTxs).Error; err != nil {
 				return
 			}
-			if err = db.Delete(&model.Reward{}, "block_number>?", head).Error; err != nil {
+		}
+		for _, cacheTransferLog := range parsed.CacheTransferLogs {
+			if err = db.Create(cacheTransferLog).Error; err != nil {
 				return
 			}
-			if err = db.Delete(&model.Transaction{}, "block_number>?", head).Error; err != nil {
-				return
+		}
+		if err = saveBalances(db, parsed.CacheTransferLogs, 1); err != nil {
+			return
+		}
+		if err = saveTokens(db, parsed); err != nil {
+			return
+		}
+		if err = saveProxies(db, parsed); err != nil {
+			return
+		}
+		// the owners are set from all the stored transfers, the later blocks may have transferred the tokens again
+		var transferred []*model.ERC721Token
+		if transferred, err = inventoryTokens(db, parsed.Number, parsed.Number); err != nil {
+			return
+		}
+		if err = rebuildInventory(db, append(nfts, transferred...)); err != nil {
+			return
+		}
+		if err = db.Create(parsed.Block).Error; err != nil {
+			return
+		}
+		for _, erbie := range parsed.Erbies {
+			if erbie.TxHash != "0x0" {
+				if err = db.Create(erbie).Error; err != nil {
+					return
+				}
 			}
-			if err = db.Delete(&model.Block{}, "number>?", head).Error; err != nil {
+		}
+		if len(parsed.Rewards) > 0 {
+			if err = db.Create(parsed.Rewards).Error; err != nil {
 				return
 			}
-			return fixStats(db, parsed)
-		} else {
-			if err = model.ClearTable(db); err != nil {
+			if err = db.Exec("UPDATE rewards SET proxy=(SELECT proxy FROM validators WHERE address=rewards.address) WHERE block_number=? AND snft=''", parsed.Number).Error; err != nil {
 				return
 			}
-			return initStats(db)
 		}
+		if len(parsed.Slashings) > 0 {
+			err = db.Create(parsed.Slashings).Error
+		}
+		return
 	})
 }
 
+// RefreshStats reloads the query stats counted from the database
+func RefreshStats() error {
+	return loadStats(DB)
+}
+
+// WatchStats reloads the stats every interval until the context is cancelled, the API running without the indexer
+// sees the blocks written by the indexer process
+func WatchStats(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := loadStats(DB); err != nil {
+				log.Printf("Failed to reload the stats: %v\n", err)
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// StoredHead returns the number of the last stored block, -1 when none is stored
+func StoredHead() (head types.Long, err error) {
+	err = DB.Model(&model.Block{}).Select("COALESCE(MAX(`number`),-1)").Scan(&head).Error
+	return
+}
+
 // injectSNFT official batch injection of SNFT
 func injectSNFT(db *gorm.DB, wh *model.Parsed) (err error) {
 	if epoch := wh.Epoch; epoch != nil {
@@ -415,6 +643,7 @@ func saveErbie(db *gorm.DB, wh *model.Parsed) (err error) {
 			pledge.Timestamp = erbie.Timestamp
 			pledge.BlockNumber = erbie.BlockNumber
 			if err = db.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "staker"}, {Name: "validator"}},
 				DoUpdates: clause.AssignmentColumns([]string{"amount", "timestamp", "block_number"}),
 			}).Create(&pledge).Error; err != nil {
 				return
@@ -429,6 +658,7 @@ func saveErbie(db *gorm.DB, wh *model.Parsed) (err error) {
 			staker.Timestamp = erbie.Timestamp
 			staker.BlockNumber = erbie.BlockNumber
 			if err = db.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "address"}},
 				DoUpdates: clause.AssignmentColumns([]string{"fee_rate", "amount", "timestamp", "block_number"}),
 			}).Create(&staker).Error; err != nil {
 				return
@@ -447,6 +677,7 @@ func saveErbie(db *gorm.DB, wh *model.Parsed) (err error) {
 			validator.Timestamp = erbie.Timestamp
 			validator.BlockNumber = erbie.BlockNumber
 			if err = db.Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "address"}},
 				DoUpdates: clause.AssignmentColumns([]string{"amount", "timestamp", "block_number"}),
 			}).Create(&validator).Error; err != nil {
 				return